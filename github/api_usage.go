@@ -0,0 +1,352 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// apiUsageReportPathPID is replaced by the process ID in report paths.
+const apiUsageReportPathPID = "{pid}"
+
+var (
+	// apiUsageCollectors holds the collectors of every configured provider
+	// instance of the process, reported once the provider shuts down.
+	apiUsageCollectors   []*apiUsage
+	apiUsageCollectorsMu sync.Mutex
+
+	// apiUsageNumericSegment matches path segments of numeric identifiers.
+	apiUsageNumericSegment = regexp.MustCompile(`^[0-9]+$`)
+)
+
+// apiUsageNamedSegments maps path segments to the names of the parameters
+// following them, used to derive endpoint templates from request paths.
+var apiUsageNamedSegments = map[string][]string{
+	"repos":         {"{owner}", "{repo}"},
+	"orgs":          {"{org}"},
+	"users":         {"{username}"},
+	"enterprises":   {"{enterprise}"},
+	"teams":         {"{team_slug}"},
+	"branches":      {"{branch}"},
+	"environments":  {"{environment_name}"},
+	"secrets":       {"{secret_name}"},
+	"variables":     {"{name}"},
+	"labels":        {"{name}"},
+	"contents":      {"{path}"},
+	"members":       {"{username}"},
+	"memberships":   {"{username}"},
+	"collaborators": {"{username}"},
+}
+
+// apiUsageLiteralSegments are path segments which are part of an endpoint
+// although they follow a segment of apiUsageNamedSegments.
+var apiUsageLiteralSegments = map[string]bool{
+	"public-key": true,
+}
+
+// apiUsage collects metrics about the requests made by a provider instance.
+// All methods are no-ops on a nil receiver, so collection is optional.
+type apiUsage struct {
+	reportPath string
+
+	m              sync.Mutex
+	requests       map[string]int
+	notModified    int
+	retries        int
+	rateLimitWait  time.Duration
+	rateLimits     map[string]apiUsageRateLimit
+	firstRequestAt time.Time
+}
+
+// apiUsageRateLimit is the last rate limit reported for an API resource.
+type apiUsageRateLimit struct {
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	Reset     time.Time `json:"reset"`
+}
+
+// apiUsageReport is the summary of an apiUsage, written as JSON.
+type apiUsageReport struct {
+	Duration           string                       `json:"duration"`
+	TotalRequests      int                          `json:"total_requests"`
+	Requests           map[string]int               `json:"requests"`
+	NotModified        int                          `json:"not_modified"`
+	Retries            int                          `json:"retries"`
+	RateLimitWait      string                       `json:"rate_limit_wait"`
+	RateLimitWaitSecs  float64                      `json:"rate_limit_wait_seconds"`
+	RateLimitRemaining map[string]apiUsageRateLimit `json:"rate_limit_remaining"`
+
+	firstRequestAt time.Time
+	rateLimitWait  time.Duration
+}
+
+// newAPIUsage returns a registered collector whose usage is written as JSON to
+// reportPath, when set.
+func newAPIUsage(reportPath string) *apiUsage {
+	u := &apiUsage{
+		reportPath: reportPath,
+		requests:   make(map[string]int),
+		rateLimits: make(map[string]apiUsageRateLimit),
+	}
+
+	apiUsageCollectorsMu.Lock()
+	defer apiUsageCollectorsMu.Unlock()
+	apiUsageCollectors = append(apiUsageCollectors, u)
+
+	return u
+}
+
+// ReportAPIUsage logs the API usage summary of the process and writes the
+// JSON reports which were asked for. It is meant to be called once the
+// provider is shutting down. The usage of the provider instances sharing a
+// report path, such as aliases, is merged into a single report.
+//
+// Terraform runs the provider in a new process for each of its phases, so a
+// report only covers the process that wrote it.
+func ReportAPIUsage() {
+	apiUsageCollectorsMu.Lock()
+	collectors := apiUsageCollectors
+	apiUsageCollectors = nil
+	apiUsageCollectorsMu.Unlock()
+
+	var summaries []apiUsageReport
+	var paths []string
+	summariesByPath := make(map[string][]apiUsageReport)
+	for _, u := range collectors {
+		r := u.summary()
+		summaries = append(summaries, r)
+		if u.reportPath == "" {
+			continue
+		}
+		if _, ok := summariesByPath[u.reportPath]; !ok {
+			paths = append(paths, u.reportPath)
+		}
+		summariesByPath[u.reportPath] = append(summariesByPath[u.reportPath], r)
+	}
+
+	total := mergeAPIUsageReports(summaries)
+	if total.TotalRequests == 0 {
+		return
+	}
+	logAPIUsage(total)
+
+	for _, path := range paths {
+		r := mergeAPIUsageReports(summariesByPath[path])
+		if err := writeAPIUsageReport(path, r); err != nil {
+			log.Printf("[WARN] Unable to write API usage report: %s", err)
+		}
+	}
+}
+
+// recordResponse counts a request sent to GitHub and the rate limit its
+// response reported.
+func (u *apiUsage) recordResponse(req *http.Request, resp *http.Response) {
+	if u == nil {
+		return
+	}
+
+	u.m.Lock()
+	defer u.m.Unlock()
+
+	if u.firstRequestAt.IsZero() {
+		u.firstRequestAt = time.Now()
+	}
+	u.requests[req.Method+" "+endpointTemplate(req.URL.Path)]++
+
+	if resp == nil {
+		return
+	}
+	if resp.StatusCode == http.StatusNotModified {
+		u.notModified++
+	}
+
+	remaining, err := strconv.Atoi(resp.Header.Get("x-ratelimit-remaining"))
+	if err != nil {
+		return
+	}
+	limit, _ := strconv.Atoi(resp.Header.Get("x-ratelimit-limit"))
+	reset, _ := strconv.ParseInt(resp.Header.Get("x-ratelimit-reset"), 10, 64)
+	resource := resp.Header.Get("x-ratelimit-resource")
+	if resource == "" {
+		resource = rateLimitResource(req)
+	}
+	u.rateLimits[resource] = apiUsageRateLimit{Limit: limit, Remaining: remaining, Reset: time.Unix(reset, 0)}
+}
+
+func (u *apiUsage) recordRetry() {
+	if u == nil {
+		return
+	}
+
+	u.m.Lock()
+	defer u.m.Unlock()
+	u.retries++
+}
+
+func (u *apiUsage) recordRateLimitWait(d time.Duration) {
+	if u == nil {
+		return
+	}
+
+	u.m.Lock()
+	defer u.m.Unlock()
+	u.rateLimitWait += d
+}
+
+func (u *apiUsage) summary() apiUsageReport {
+	u.m.Lock()
+	defer u.m.Unlock()
+
+	r := apiUsageReport{
+		Requests:           make(map[string]int, len(u.requests)),
+		NotModified:        u.notModified,
+		Retries:            u.retries,
+		RateLimitWait:      u.rateLimitWait.String(),
+		RateLimitWaitSecs:  u.rateLimitWait.Seconds(),
+		RateLimitRemaining: make(map[string]apiUsageRateLimit, len(u.rateLimits)),
+		firstRequestAt:     u.firstRequestAt,
+		rateLimitWait:      u.rateLimitWait,
+	}
+	for endpoint, count := range u.requests {
+		r.Requests[endpoint] = count
+		r.TotalRequests += count
+	}
+	for resource, rateLimit := range u.rateLimits {
+		r.RateLimitRemaining[resource] = rateLimit
+	}
+
+	return r
+}
+
+// mergeAPIUsageReports sums up reports. The remaining quota of a rate limit
+// is the lowest one reported for its latest reset.
+func mergeAPIUsageReports(reports []apiUsageReport) apiUsageReport {
+	merged := apiUsageReport{
+		Requests:           make(map[string]int),
+		RateLimitRemaining: make(map[string]apiUsageRateLimit),
+	}
+
+	for _, r := range reports {
+		for endpoint, count := range r.Requests {
+			merged.Requests[endpoint] += count
+		}
+		merged.TotalRequests += r.TotalRequests
+		merged.NotModified += r.NotModified
+		merged.Retries += r.Retries
+		merged.rateLimitWait += r.rateLimitWait
+		for resource, rateLimit := range r.RateLimitRemaining {
+			current, ok := merged.RateLimitRemaining[resource]
+			if !ok || rateLimit.Reset.After(current.Reset) || rateLimit.Reset.Equal(current.Reset) && rateLimit.Remaining < current.Remaining {
+				merged.RateLimitRemaining[resource] = rateLimit
+			}
+		}
+		if !r.firstRequestAt.IsZero() && (merged.firstRequestAt.IsZero() || r.firstRequestAt.Before(merged.firstRequestAt)) {
+			merged.firstRequestAt = r.firstRequestAt
+		}
+	}
+
+	merged.RateLimitWait = merged.rateLimitWait.String()
+	merged.RateLimitWaitSecs = merged.rateLimitWait.Seconds()
+	if !merged.firstRequestAt.IsZero() {
+		merged.Duration = time.Since(merged.firstRequestAt).Round(time.Millisecond).String()
+	}
+
+	return merged
+}
+
+// logAPIUsage logs the summary with the standard logger, as the context of
+// the request that configured the provider is over by the time it exits.
+func logAPIUsage(r apiUsageReport) {
+	// Most requested endpoints first, as these are the costly ones.
+	endpoints := make([]string, 0, len(r.Requests))
+	for endpoint := range r.Requests {
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Slice(endpoints, func(i, j int) bool {
+		if r.Requests[endpoints[i]] != r.Requests[endpoints[j]] {
+			return r.Requests[endpoints[i]] > r.Requests[endpoints[j]]
+		}
+		return endpoints[i] < endpoints[j]
+	})
+	topEndpoints := make([]string, 0, 10)
+	for _, endpoint := range endpoints[:min(len(endpoints), 10)] {
+		topEndpoints = append(topEndpoints, fmt.Sprintf("%s (%d)", endpoint, r.Requests[endpoint]))
+	}
+
+	remaining := make(map[string]int, len(r.RateLimitRemaining))
+	for resource, rateLimit := range r.RateLimitRemaining {
+		remaining[resource] = rateLimit.Remaining
+	}
+
+	log.Printf("[INFO] GitHub API usage: pid=%d duration=%s total_requests=%d top_endpoints=%q not_modified=%d retries=%d rate_limit_wait=%s rate_limit_remaining=%v",
+		os.Getpid(), r.Duration, r.TotalRequests, strings.Join(topEndpoints, ", "), r.NotModified, r.Retries, r.RateLimitWait, remaining)
+}
+
+// writeAPIUsageReport writes a report as JSON to path, where {pid} is
+// replaced by the process ID so that each process can keep its own report.
+func writeAPIUsageReport(path string, r apiUsageReport) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	path = strings.ReplaceAll(path, apiUsageReportPathPID, strconv.Itoa(os.Getpid()))
+	return os.WriteFile(path, data, 0o644)
+}
+
+// endpointTemplate replaces the parameters of a REST API path with
+// placeholders, so that requests to the same endpoint are counted together,
+// e.g. /repos/octo/hello/issues/1 becomes /repos/{owner}/{repo}/issues/{id}.
+func endpointTemplate(path string) string {
+	path = strings.TrimPrefix(path, "/api/v3")
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	for i := 0; i < len(segments); i++ {
+		segment := segments[i]
+		if apiUsageNumericSegment.MatchString(segment) {
+			segments[i] = "{id}"
+			continue
+		}
+
+		params, ok := apiUsageNamedSegments[segment]
+		if !ok {
+			continue
+		}
+		for _, param := range params {
+			if i+1 >= len(segments) || apiUsageLiteralSegments[segments[i+1]] {
+				break
+			}
+			i++
+			segments[i] = param
+		}
+		// File paths span the remaining segments.
+		if segment == "contents" && i+1 < len(segments) {
+			segments = segments[:i+1]
+		}
+	}
+
+	return "/" + strings.Join(segments, "/")
+}
+
+// apiUsageTransport counts every request sent to GitHub.
+type apiUsageTransport struct {
+	transport http.RoundTripper
+	usage     *apiUsage
+}
+
+func newAPIUsageTransport(rt http.RoundTripper, usage *apiUsage) *apiUsageTransport {
+	return &apiUsageTransport{transport: rt, usage: usage}
+}
+
+func (ut *apiUsageTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := ut.transport.RoundTrip(req)
+	ut.usage.recordResponse(req, resp)
+	return resp, err
+}
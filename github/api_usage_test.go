@@ -0,0 +1,144 @@
+package github
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEndpointTemplate(t *testing.T) {
+	testCases := []struct {
+		path     string
+		expected string
+	}{
+		{path: "/repos/octo/hello/issues/12", expected: "/repos/{owner}/{repo}/issues/{id}"},
+		{path: "/api/v3/orgs/octo/teams/core/repos/octo/hello", expected: "/orgs/{org}/teams/{team_slug}/repos/{owner}/{repo}"},
+		{path: "/repos/octo/hello/contents/docs/README.md", expected: "/repos/{owner}/{repo}/contents/{path}"},
+		{path: "/orgs/octo/actions/secrets/public-key", expected: "/orgs/{org}/actions/secrets/public-key"},
+		{path: "/user/repos", expected: "/user/repos"},
+		{path: "/graphql", expected: "/graphql"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.path, func(t *testing.T) {
+			if template := endpointTemplate(tc.path); template != tc.expected {
+				t.Errorf("Expected template %q, got %q", tc.expected, template)
+			}
+		})
+	}
+}
+
+func TestAPIUsage(t *testing.T) {
+	ts := githubApiMock([]*mockResponse{
+		{
+			ExpectedUri: "/repos/octo/hello",
+			ResponseHeaders: map[string]string{
+				"x-ratelimit-resource":  "core",
+				"x-ratelimit-limit":     "5000",
+				"x-ratelimit-remaining": "4999",
+				"x-ratelimit-reset":     "1700000000",
+			},
+			ResponseBody: `{"id": 1234}`,
+			StatusCode:   200,
+		},
+		{
+			ExpectedUri: "/repos/octo/world",
+			StatusCode:  304,
+		},
+	})
+	defer ts.Close()
+
+	reportPath := filepath.Join(t.TempDir(), "usage.json")
+	usage := newAPIUsage(reportPath)
+	usage.recordRetry()
+	usage.recordRateLimitWait(time.Second)
+
+	client := &http.Client{Transport: newAPIUsageTransport(http.DefaultTransport, usage)}
+	for _, repo := range []string{"hello", "world"} {
+		resp, err := client.Get(ts.URL + "/repos/octo/" + repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	ReportAPIUsage()
+	log.SetOutput(os.Stderr)
+
+	if !strings.Contains(logs.String(), "[INFO] GitHub API usage: ") || !strings.Contains(logs.String(), "total_requests=2") {
+		t.Errorf("Expected the summary to be logged, got: %s", logs.String())
+	}
+
+	data, err := os.ReadFile(reportPath)
+	if err != nil {
+		t.Fatalf("Expected the report to be written: %s", err)
+	}
+
+	var report apiUsageReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatal(err)
+	}
+
+	if report.TotalRequests != 2 || report.Requests["GET /repos/{owner}/{repo}"] != 2 {
+		t.Errorf("Expected 2 requests to GET /repos/{owner}/{repo}, got: %v", report.Requests)
+	}
+	if report.NotModified != 1 {
+		t.Errorf("Expected 1 not modified response, got: %d", report.NotModified)
+	}
+	if report.Retries != 1 {
+		t.Errorf("Expected 1 retry, got: %d", report.Retries)
+	}
+	if report.RateLimitWaitSecs != 1 {
+		t.Errorf("Expected 1s spent waiting on rate limits, got: %f", report.RateLimitWaitSecs)
+	}
+	if report.RateLimitRemaining["core"].Remaining != 4999 {
+		t.Errorf("Expected 4999 core requests remaining, got: %v", report.RateLimitRemaining)
+	}
+}
+
+func TestReportAPIUsage_merged(t *testing.T) {
+	dir := t.TempDir()
+	reportPath := filepath.Join(dir, "usage-{pid}.json")
+
+	for _, remaining := range []int{10, 4} {
+		usage := newAPIUsage(reportPath)
+		req, _ := http.NewRequest("GET", "https://api.github.com/repos/octo/hello", nil)
+		resp := &http.Response{StatusCode: 200, Header: http.Header{}}
+		resp.Header.Set("x-ratelimit-resource", "core")
+		resp.Header.Set("x-ratelimit-remaining", strconv.Itoa(remaining))
+		resp.Header.Set("x-ratelimit-reset", "1700000000")
+		usage.recordResponse(req, resp)
+		usage.recordRateLimitWait(time.Second)
+	}
+
+	ReportAPIUsage()
+
+	data, err := os.ReadFile(filepath.Join(dir, fmt.Sprintf("usage-%d.json", os.Getpid())))
+	if err != nil {
+		t.Fatalf("Expected the report to be written for the process: %s", err)
+	}
+
+	var report apiUsageReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatal(err)
+	}
+	if report.TotalRequests != 2 {
+		t.Errorf("Expected the requests of both instances to be reported, got: %d", report.TotalRequests)
+	}
+	if report.RateLimitWaitSecs != 2 {
+		t.Errorf("Expected 2s spent waiting on rate limits, got: %f", report.RateLimitWaitSecs)
+	}
+	if report.RateLimitRemaining["core"].Remaining != 4 {
+		t.Errorf("Expected the lowest remaining quota to be reported, got: %v", report.RateLimitRemaining)
+	}
+}
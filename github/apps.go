@@ -1,28 +1,38 @@
 package github
 
 import (
+	"bytes"
 	"crypto/x509"
 	"encoding/json"
 	"encoding/pem"
 	"errors"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-jose/go-jose/v3"
 	"github.com/go-jose/go-jose/v3/jwt"
+	"golang.org/x/oauth2"
 )
 
+// appTokenRefreshWindow is how long before its expiry an installation token
+// is considered stale and re-minted.
+const appTokenRefreshWindow = 5 * time.Minute
+
 // GenerateOAuthTokenFromApp generates a GitHub OAuth access token from a set of valid GitHub App credentials.
 // The returned token can be used to interact with both GitHub's REST and GraphQL APIs.
-func GenerateOAuthTokenFromApp(baseURL, appID, appInstallationID, pemData string) (string, error) {
+func GenerateOAuthTokenFromApp(client *http.Client, baseURL, appID, appInstallationID, pemData string) (string, error) {
 	appJWT, err := generateAppJWT(appID, time.Now(), []byte(pemData))
 	if err != nil {
 		return "", err
 	}
 
-	token, err := getInstallationAccessToken(baseURL, appJWT, appInstallationID)
+	token, err := getInstallationAccessToken(client, baseURL, appJWT, appInstallationID)
 	if err != nil {
 		return "", err
 	}
@@ -30,46 +40,201 @@ func GenerateOAuthTokenFromApp(baseURL, appID, appInstallationID, pemData string
 	return token, nil
 }
 
-func getInstallationAccessToken(baseURL string, jwt string, installationID string) (string, error) {
-	if baseURL != "https://api.github.com/" && !GHECDataResidencyMatch.MatchString(baseURL) {
-		baseURL += "api/v3/"
-	}
-
-	url := fmt.Sprintf("%sapp/installations/%s/access_tokens", baseURL, installationID)
-
-	req, err := http.NewRequest(http.MethodPost, url, nil)
+func getInstallationAccessToken(client *http.Client, baseURL string, jwt string, installationID string) (string, error) {
+	token, err := getInstallationToken(client, baseURL, jwt, installationID)
 	if err != nil {
 		return "", err
 	}
 
-	req.Header.Add("Accept", "application/vnd.github.v3+json")
-	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", jwt))
+	return token.AccessToken, nil
+}
 
-	res, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return "", err
+// getInstallationToken exchanges an App JWT for an installation access token,
+// keeping the expiry reported by the API.
+func getInstallationToken(client *http.Client, baseURL string, jwt string, installationID string) (*oauth2.Token, error) {
+	return getScopedInstallationToken(client, baseURL, jwt, installationID, nil)
+}
+
+// installationTokenScope narrows the repositories and permissions granted to
+// an installation access token, which otherwise gets every repository and
+// permission of the installation.
+type installationTokenScope struct {
+	Repositories []string          `json:"repositories,omitempty"`
+	Permissions  map[string]string `json:"permissions,omitempty"`
+}
+
+// getScopedInstallationToken exchanges an App JWT for an installation access
+// token restricted to scope, when not nil.
+func getScopedInstallationToken(client *http.Client, baseURL string, jwt string, installationID string, scope *installationTokenScope) (*oauth2.Token, error) {
+	url := fmt.Sprintf("%sapp/installations/%s/access_tokens", appAPIURL(baseURL), installationID)
+
+	var body []byte
+	if scope != nil {
+		var err error
+		if body, err = json.Marshal(scope); err != nil {
+			return nil, err
+		}
 	}
-	defer func() { _ = res.Body.Close() }()
 
-	resBytes, err := io.ReadAll(res.Body)
+	status, resBytes, err := doAppRequest(client, http.MethodPost, url, jwt, body)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	if res.StatusCode != http.StatusCreated {
-		return "", fmt.Errorf("failed to create OAuth token from GitHub App: %s", string(resBytes))
+	if status != http.StatusCreated {
+		return nil, fmt.Errorf("failed to create OAuth token from GitHub App: %s", string(resBytes))
 	}
 
 	resData := struct {
-		Token string `json:"token"`
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
 	}{}
 
 	err = json.Unmarshal(resBytes, &resData)
+	if err != nil {
+		return nil, err
+	}
+
+	return &oauth2.Token{
+		AccessToken: resData.Token,
+		TokenType:   "Bearer",
+		Expiry:      resData.ExpiresAt,
+	}, nil
+}
+
+// appInstallation is the subset of an App installation returned by the API
+// needed to identify it.
+type appInstallation struct {
+	ID      int64 `json:"id"`
+	Account struct {
+		Login string `json:"login"`
+		Type  string `json:"type"`
+	} `json:"account"`
+}
+
+// GetInstallationIDForOwner looks up the installation of a GitHub App on the
+// given organization or user account, using the App's own credentials.
+func GetInstallationIDForOwner(client *http.Client, baseURL, appID, owner, pemData string) (string, error) {
+	appJWT, err := generateAppJWT(appID, time.Now(), []byte(pemData))
 	if err != nil {
 		return "", err
 	}
 
-	return resData.Token, nil
+	return getInstallationIDForOwner(client, baseURL, appJWT, owner)
+}
+
+func getInstallationIDForOwner(client *http.Client, baseURL string, jwt string, owner string) (string, error) {
+	apiURL := appAPIURL(baseURL)
+
+	for _, kind := range []string{"orgs", "users"} {
+		url := fmt.Sprintf("%s%s/%s/installation", apiURL, kind, owner)
+
+		status, resBytes, err := doAppRequest(client, http.MethodGet, url, jwt, nil)
+		if err != nil {
+			return "", err
+		}
+
+		if status == http.StatusNotFound {
+			continue
+		}
+		if status != http.StatusOK {
+			return "", fmt.Errorf("failed to look up GitHub App installation for %q: %s", owner, string(resBytes))
+		}
+
+		var installation appInstallation
+		if err = json.Unmarshal(resBytes, &installation); err != nil {
+			return "", err
+		}
+
+		return strconv.FormatInt(installation.ID, 10), nil
+	}
+
+	installations, err := listAppInstallations(client, apiURL, jwt)
+	if err != nil {
+		return "", fmt.Errorf("no GitHub App installation found for %q, and listing installations failed: %w", owner, err)
+	}
+
+	if len(installations) == 0 {
+		return "", fmt.Errorf("no GitHub App installation found for %q: the App is not installed on any account", owner)
+	}
+
+	available := make([]string, 0, len(installations))
+	for _, installation := range installations {
+		available = append(available, fmt.Sprintf("%s (%s, installation_id %d)",
+			installation.Account.Login, installation.Account.Type, installation.ID))
+	}
+
+	return "", fmt.Errorf("no GitHub App installation found for %q, available installations: %s",
+		owner, strings.Join(available, ", "))
+}
+
+func listAppInstallations(client *http.Client, apiURL string, jwt string) ([]appInstallation, error) {
+	var installations []appInstallation
+
+	for page := 1; ; page++ {
+		url := fmt.Sprintf("%sapp/installations?per_page=%d&page=%d", apiURL, maxPerPage, page)
+
+		status, resBytes, err := doAppRequest(client, http.MethodGet, url, jwt, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		if status != http.StatusOK {
+			return nil, fmt.Errorf("failed to list GitHub App installations: %s", string(resBytes))
+		}
+
+		var pageInstallations []appInstallation
+		if err = json.Unmarshal(resBytes, &pageInstallations); err != nil {
+			return nil, err
+		}
+
+		installations = append(installations, pageInstallations...)
+		if len(pageInstallations) < maxPerPage {
+			return installations, nil
+		}
+	}
+}
+
+// appAPIURL returns the REST API root for baseURL, which differs between
+// GitHub.com, GHEC data residency and GitHub Enterprise Server.
+func appAPIURL(baseURL string) string {
+	if baseURL != "https://api.github.com/" && !GHECDataResidencyMatch.MatchString(baseURL) {
+		return baseURL + "api/v3/"
+	}
+	return baseURL
+}
+
+// doAppRequest performs a request authenticated as the GitHub App itself and
+// returns the response status and body. A non-nil body is sent as JSON.
+func doAppRequest(client *http.Client, method string, url string, jwt string, body []byte) (int, []byte, error) {
+	var reqBody io.Reader
+	if body != nil {
+		reqBody = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequest(method, url, reqBody)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Add("Accept", "application/vnd.github.v3+json")
+	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", jwt))
+
+	res, err := client.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	resBytes, err := io.ReadAll(res.Body)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return res.StatusCode, resBytes, nil
 }
 
 func generateAppJWT(appID string, now time.Time, pemData []byte) (string, error) {
@@ -108,3 +273,65 @@ func generateAppJWT(appID string, now time.Time, pemData []byte) (string, error)
 
 	return token, nil
 }
+
+// appInstallationTokenSource is an oauth2.TokenSource minting GitHub App
+// installation tokens. Tokens are cached and re-minted, with a freshly signed
+// App JWT, shortly before they expire or after being invalidated following a
+// 401 response.
+type appInstallationTokenSource struct {
+	client         *http.Client
+	baseURL        string
+	appID          string
+	installationID string
+	pemData        []byte
+
+	m     sync.Mutex
+	token *oauth2.Token
+}
+
+// NewAppInstallationTokenSource returns a token source for the given GitHub
+// App installation which transparently refreshes the installation token.
+func NewAppInstallationTokenSource(client *http.Client, baseURL, appID, appInstallationID, pemData string) *appInstallationTokenSource {
+	return &appInstallationTokenSource{
+		client:         client,
+		baseURL:        baseURL,
+		appID:          appID,
+		installationID: appInstallationID,
+		pemData:        []byte(pemData),
+	}
+}
+
+func (ts *appInstallationTokenSource) Token() (*oauth2.Token, error) {
+	ts.m.Lock()
+	defer ts.m.Unlock()
+
+	if ts.token != nil && (ts.token.Expiry.IsZero() || time.Until(ts.token.Expiry) > appTokenRefreshWindow) {
+		return ts.token, nil
+	}
+
+	appJWT, err := generateAppJWT(ts.appID, time.Now(), ts.pemData)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := getInstallationToken(ts.client, ts.baseURL, appJWT, ts.installationID)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("[DEBUG] Minted GitHub App installation token expiring at %s", token.Expiry)
+
+	ts.token = token
+	return token, nil
+}
+
+// invalidate drops the cached token if it is still the given access token,
+// forcing the next call to Token to mint a new one. Comparing against the
+// rejected token avoids re-minting once per concurrent 401 response.
+func (ts *appInstallationTokenSource) invalidate(accessToken string) {
+	ts.m.Lock()
+	defer ts.m.Unlock()
+
+	if ts.token != nil && ts.token.AccessToken == accessToken {
+		ts.token = nil
+	}
+}
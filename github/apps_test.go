@@ -5,6 +5,7 @@ import (
 	"crypto/x509"
 	"encoding/pem"
 	"fmt"
+	"net/http"
 	"os"
 	"strings"
 	"testing"
@@ -157,7 +158,7 @@ func TestGetInstallationAccessToken(t *testing.T) {
 	})
 	defer ts.Close()
 
-	accessToken, err := getInstallationAccessToken(ts.URL+"/", fakeJWT, testGitHubAppInstallationID)
+	accessToken, err := getInstallationAccessToken(http.DefaultClient, ts.URL+"/", fakeJWT, testGitHubAppInstallationID)
 
 	if err != nil {
 		t.Logf("Unexpected error: %s", err)
@@ -169,3 +170,170 @@ func TestGetInstallationAccessToken(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestGetScopedInstallationToken(t *testing.T) {
+	ts := githubApiMock([]*mockResponse{
+		{
+			ExpectedUri:    fmt.Sprintf("/api/v3/app/installations/%s/access_tokens", testGitHubAppInstallationID),
+			ExpectedMethod: "POST",
+			ExpectedHeaders: map[string]string{
+				"Content-Type": "application/json",
+			},
+			ExpectedBody: []byte(`{"repositories":["hello-world"],"permissions":{"contents":"read"}}`),
+
+			ResponseBody: `{"token": "scoped", "expires_at": "2030-01-01T00:00:00Z"}`,
+			StatusCode:   201,
+		},
+	})
+	defer ts.Close()
+
+	scope := &installationTokenScope{
+		Repositories: []string{"hello-world"},
+		Permissions:  map[string]string{"contents": "read"},
+	}
+	token, err := getScopedInstallationToken(http.DefaultClient, ts.URL+"/", "jwt", testGitHubAppInstallationID, scope)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if token.AccessToken != "scoped" {
+		t.Errorf("Unexpected access token - Found: %s - Expected: scoped", token.AccessToken)
+	}
+	if !token.Expiry.Equal(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected expiry: %s", token.Expiry)
+	}
+}
+
+func TestAppInstallationTokenSource(t *testing.T) {
+	tokenURI := fmt.Sprintf("/api/v3/app/installations/%s/access_tokens", testGitHubAppInstallationID)
+	tokenResponse := func(token string, expiresAt time.Time) *mockResponse {
+		return &mockResponse{
+			ExpectedUri:    tokenURI,
+			ExpectedMethod: "POST",
+			ResponseBody:   fmt.Sprintf(`{"token": "%s", "expires_at": "%s"}`, token, expiresAt.UTC().Format(time.RFC3339)),
+			StatusCode:     201,
+		}
+	}
+
+	ts := githubApiMock([]*mockResponse{
+		tokenResponse("expiring", time.Now().Add(time.Minute)),
+		tokenResponse("fresh", time.Now().Add(time.Hour)),
+		tokenResponse("refreshed", time.Now().Add(time.Hour)),
+	})
+	defer ts.Close()
+
+	source := NewAppInstallationTokenSource(http.DefaultClient, ts.URL+"/", testGitHubAppID, testGitHubAppInstallationID, string(testGitHubAppPrivateKeyPemData))
+
+	expectToken := func(t *testing.T, expected string) {
+		token, err := source.Token()
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if token.AccessToken != expected {
+			t.Fatalf("Unexpected access token - Found: %s - Expected: %s", token.AccessToken, expected)
+		}
+	}
+
+	t.Run("mints a token", func(t *testing.T) {
+		expectToken(t, "expiring")
+	})
+
+	t.Run("re-mints a token about to expire", func(t *testing.T) {
+		expectToken(t, "fresh")
+	})
+
+	t.Run("reuses a valid token", func(t *testing.T) {
+		expectToken(t, "fresh")
+	})
+
+	t.Run("ignores invalidation of a previous token", func(t *testing.T) {
+		source.invalidate("expiring")
+		expectToken(t, "fresh")
+	})
+
+	t.Run("re-mints an invalidated token", func(t *testing.T) {
+		source.invalidate("fresh")
+		expectToken(t, "refreshed")
+	})
+}
+
+func TestGetInstallationIDForOwner(t *testing.T) {
+	fakeJWT := "fake.app.jwt"
+
+	t.Run("finds an organization installation", func(t *testing.T) {
+		ts := githubApiMock([]*mockResponse{
+			{
+				ExpectedUri: "/api/v3/orgs/octo-org/installation",
+				ExpectedHeaders: map[string]string{
+					"Authorization": fmt.Sprintf("Bearer %s", fakeJWT),
+				},
+				ResponseBody: `{"id": 1234, "account": {"login": "octo-org", "type": "Organization"}}`,
+				StatusCode:   200,
+			},
+		})
+		defer ts.Close()
+
+		installationID, err := getInstallationIDForOwner(http.DefaultClient, ts.URL+"/", fakeJWT, "octo-org")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if installationID != "1234" {
+			t.Fatalf("Unexpected installation ID - Found: %s - Expected: 1234", installationID)
+		}
+	})
+
+	t.Run("falls back to a user installation", func(t *testing.T) {
+		ts := githubApiMock([]*mockResponse{
+			{
+				ExpectedUri:  "/api/v3/orgs/octocat/installation",
+				ResponseBody: `{"message": "Not Found"}`,
+				StatusCode:   404,
+			},
+			{
+				ExpectedUri:  "/api/v3/users/octocat/installation",
+				ResponseBody: `{"id": 5678, "account": {"login": "octocat", "type": "User"}}`,
+				StatusCode:   200,
+			},
+		})
+		defer ts.Close()
+
+		installationID, err := getInstallationIDForOwner(http.DefaultClient, ts.URL+"/", fakeJWT, "octocat")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if installationID != "5678" {
+			t.Fatalf("Unexpected installation ID - Found: %s - Expected: 5678", installationID)
+		}
+	})
+
+	t.Run("lists available installations when none matches", func(t *testing.T) {
+		ts := githubApiMock([]*mockResponse{
+			{
+				ExpectedUri:  "/api/v3/orgs/unknown/installation",
+				ResponseBody: `{"message": "Not Found"}`,
+				StatusCode:   404,
+			},
+			{
+				ExpectedUri:  "/api/v3/users/unknown/installation",
+				ResponseBody: `{"message": "Not Found"}`,
+				StatusCode:   404,
+			},
+			{
+				ExpectedUri:  fmt.Sprintf("/api/v3/app/installations?per_page=%d&page=1", maxPerPage),
+				ResponseBody: `[{"id": 1234, "account": {"login": "octo-org", "type": "Organization"}}]`,
+				StatusCode:   200,
+			},
+		})
+		defer ts.Close()
+
+		_, err := getInstallationIDForOwner(http.DefaultClient, ts.URL+"/", fakeJWT, "unknown")
+		if err == nil {
+			t.Fatal("Expected error not to be nil")
+		}
+
+		expected := `no GitHub App installation found for "unknown", available installations: octo-org (Organization, installation_id 1234)`
+		if err.Error() != expected {
+			t.Fatalf("Unexpected error - Found: %s - Expected: %s", err, expected)
+		}
+	})
+}
@@ -2,6 +2,13 @@ package github
 
 import (
 	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
 	"net/http"
 	"net/url"
 	"path"
@@ -17,15 +24,35 @@ import (
 
 type Config struct {
 	Token            string
+	TokenSource      oauth2.TokenSource
 	Owner            string
 	BaseURL          string
 	Insecure         bool
+	CACertPEM        string
+	Transport        http.RoundTripper
 	WriteDelay       time.Duration
 	ReadDelay        time.Duration
 	RetryDelay       time.Duration
 	RetryableErrors  map[int]bool
 	MaxRetries       int
+	Retry            RetryPolicy
 	ParallelRequests bool
+	MaxConcurrency   int
+	HTTPCache        bool
+	HTTPCacheDir     string
+
+	// usage collects API usage metrics, when set.
+	usage *apiUsage
+}
+
+// RetryPolicy configures the backoff between retries of a failed request.
+// Zero durations and multiplier fall back to the RetryTransport defaults.
+type RetryPolicy struct {
+	MaxDelay             time.Duration
+	Multiplier           float64
+	Jitter               float64
+	Timeout              time.Duration
+	DisableNonIdempotent bool
 }
 
 type Owner struct {
@@ -33,6 +60,8 @@ type Owner struct {
 	id             int64
 	v3client       *github.Client
 	v4client       *githubv4.Client
+	v4url          string
+	transport      http.RoundTripper
 	StopContext    context.Context
 	IsOrganization bool
 }
@@ -41,18 +70,56 @@ type Owner struct {
 // https://[hostname].ghe.com instances expect paths that behave similar to GitHub.com, not GitHub Enterprise Server.
 var GHECDataResidencyMatch = regexp.MustCompile(`^https:\/\/[a-zA-Z0-9.\-]*\.ghe\.com$`)
 
-func RateLimitedHTTPClient(client *http.Client, writeDelay time.Duration, readDelay time.Duration, retryDelay time.Duration, parallelRequests bool, retryableErrors map[int]bool, maxRetries int) *http.Client {
+// NewHTTPTransport returns the base transport shared by every request the
+// provider makes, including GitHub App token minting. It disables TLS
+// verification when insecure is set and trusts the certificates in caCertPEM
+// in addition to the system roots.
+func NewHTTPTransport(insecure bool, caCertPEM string) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if !insecure && caCertPEM == "" {
+		return transport, nil
+	}
+
+	tlsConfig := &tls.Config{
+		// Only enabled through the provider's `insecure` argument.
+		InsecureSkipVerify: insecure, // #nosec G402
+	}
+
+	if caCertPEM != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			log.Printf("[WARN] Unable to load system certificate pool, only using the provided CA certificates: %s", err)
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM([]byte(caCertPEM)) {
+			return nil, errors.New("no PEM encoded certificates could be parsed from the provided CA certificates")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	transport.TLSClientConfig = tlsConfig
+	return transport, nil
+}
+
+// RateLimitedHTTPClient wraps the transport of client with the provider's
+// rate limiting, retry, logging and API usage tracking transports.
+func (c *Config) RateLimitedHTTPClient(client *http.Client) *http.Client {
 
 	client.Transport = NewEtagTransport(client.Transport)
-	client.Transport = NewRateLimitTransport(client.Transport, WithWriteDelay(writeDelay), WithReadDelay(readDelay), WithParallelRequests(parallelRequests))
+	rateLimitOptions := []RateLimitTransportOption{WithWriteDelay(c.WriteDelay), WithReadDelay(c.ReadDelay), WithParallelRequests(c.ParallelRequests), WithRateLimitAPIUsage(c.usage)}
+	if c.MaxConcurrency > 0 {
+		rateLimitOptions = append(rateLimitOptions, WithMaxConcurrency(c.MaxConcurrency))
+	}
+	client.Transport = NewRateLimitTransport(client.Transport, rateLimitOptions...)
 	client.Transport = logging.NewSubsystemLoggingHTTPTransport("GitHub", client.Transport)
 	client.Transport = newPreviewHeaderInjectorTransport(map[string]string{
 		// TODO: remove when Stone Crop preview is moved to general availability in the GraphQL API
 		"Accept": "application/vnd.github.stone-crop-preview+json",
 	}, client.Transport)
 
-	if maxRetries > 0 {
-		client.Transport = NewRetryTransport(client.Transport, WithRetryDelay(retryDelay), WithRetryableErrors(retryableErrors), WithMaxRetries(maxRetries))
+	if c.MaxRetries > 0 {
+		options := append([]RetryTransportOption{WithRetryDelay(c.RetryDelay), WithRetryableErrors(c.RetryableErrors), WithMaxRetries(c.MaxRetries), WithRetryAPIUsage(c.usage)}, c.Retry.options()...)
+		client.Transport = NewRetryTransport(client.Transport, options...)
 	}
 
 	return client
@@ -60,13 +127,26 @@ func RateLimitedHTTPClient(client *http.Client, writeDelay time.Duration, readDe
 
 func (c *Config) AuthenticatedHTTPClient() *http.Client {
 
-	ctx := context.Background()
+	if c.TokenSource != nil {
+		// The token source does its own caching, so it is not wrapped in an
+		// oauth2.ReuseTokenSource which would hide invalidated tokens.
+		var transport http.RoundTripper = &oauth2.Transport{Source: c.TokenSource, Base: c.countedTransport()}
+		if ti, ok := c.TokenSource.(tokenInvalidator); ok {
+			transport = NewTokenRefreshTransport(transport, ti)
+		}
+		client := &http.Client{Transport: c.cachedTransport(transport)}
+
+		return c.RateLimitedHTTPClient(client)
+	}
+
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, &http.Client{Transport: c.countedTransport()})
 	ts := oauth2.StaticTokenSource(
 		&oauth2.Token{AccessToken: c.Token},
 	)
 	client := oauth2.NewClient(ctx, ts)
+	client.Transport = c.cachedTransport(client.Transport)
 
-	return RateLimitedHTTPClient(client, c.WriteDelay, c.ReadDelay, c.RetryDelay, c.ParallelRequests, c.RetryableErrors, c.MaxRetries)
+	return c.RateLimitedHTTPClient(client)
 }
 
 func (c *Config) Anonymous() bool {
@@ -74,24 +154,122 @@ func (c *Config) Anonymous() bool {
 }
 
 func (c *Config) AnonymousHTTPClient() *http.Client {
-	client := &http.Client{Transport: &http.Transport{}}
-	return RateLimitedHTTPClient(client, c.WriteDelay, c.ReadDelay, c.RetryDelay, c.ParallelRequests, c.RetryableErrors, c.MaxRetries)
+	client := &http.Client{Transport: c.cachedTransport(c.countedTransport())}
+	return c.RateLimitedHTTPClient(client)
+}
+
+// options converts the policy into RetryTransport options, leaving unset
+// values at their defaults.
+func (p RetryPolicy) options() []RetryTransportOption {
+	var options []RetryTransportOption
+	if p.MaxDelay > 0 {
+		options = append(options, WithMaxRetryDelay(p.MaxDelay))
+	}
+	if p.Multiplier > 0 {
+		options = append(options, WithRetryMultiplier(p.Multiplier))
+	}
+	options = append(options, WithRetryJitter(p.Jitter))
+	if p.Timeout > 0 {
+		options = append(options, WithRetryTimeout(p.Timeout))
+	}
+	if p.DisableNonIdempotent {
+		options = append(options, WithRetryNonIdempotent(false))
+	}
+	return options
+}
+
+// cachedTransport wraps rt in an HTTP cache when enabled. Authentication
+// happens below the cache, so entries are keyed by an identity derived from
+// the credentials instead of the Authorization header.
+func (c *Config) cachedTransport(rt http.RoundTripper) http.RoundTripper {
+	if !c.HTTPCache {
+		return rt
+	}
+
+	identity := "anonymous"
+	if ts, ok := c.TokenSource.(*appInstallationTokenSource); ok {
+		// Installation tokens rotate, the installation itself identifies them.
+		identity = fmt.Sprintf("app:%s:%s:%s", ts.baseURL, ts.appID, ts.installationID)
+	} else if !c.Anonymous() {
+		hash := sha256.Sum256([]byte(c.Token))
+		identity = "token:" + hex.EncodeToString(hash[:])
+	}
+
+	var options []cacheTransportOption
+	if c.HTTPCacheDir != "" {
+		options = append(options, WithCacheDir(c.HTTPCacheDir))
+	}
+
+	return NewCacheTransport(rt, identity, options...)
+}
+
+// baseTransport returns the configured shared transport, building it for
+// configurations built outside of the provider. An invalid TLS configuration
+// fails every request rather than silently dropping the settings.
+func (c *Config) baseTransport() http.RoundTripper {
+	if err := c.initTransport(); err != nil {
+		return errorTransport{err: err}
+	}
+	return c.Transport
+}
+
+// initTransport builds the shared transport from the TLS settings unless it
+// is already set.
+func (c *Config) initTransport() error {
+	if c.Transport != nil {
+		return nil
+	}
+
+	transport, err := NewHTTPTransport(c.Insecure, c.CACertPEM)
+	if err != nil {
+		return fmt.Errorf("invalid TLS configuration: %w", err)
+	}
+	c.Transport = transport
+	return nil
+}
+
+// errorTransport fails every request with err.
+type errorTransport struct {
+	err error
+}
+
+func (t errorTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, t.err
+}
+
+// countedTransport returns the base transport, counting every request sent
+// when API usage is collected.
+func (c *Config) countedTransport() http.RoundTripper {
+	if c.usage == nil {
+		return c.baseTransport()
+	}
+	return newAPIUsageTransport(c.baseTransport(), c.usage)
 }
 
 func (c *Config) NewGraphQLClient(client *http.Client) (*githubv4.Client, error) {
 
-	uv4, err := url.Parse(c.BaseURL)
+	uv4, err := c.graphQLURL()
 	if err != nil {
 		return nil, err
 	}
 
+	return githubv4.NewEnterpriseClient(uv4, client), nil
+}
+
+func (c *Config) graphQLURL() (string, error) {
+
+	uv4, err := url.Parse(c.BaseURL)
+	if err != nil {
+		return "", err
+	}
+
 	if uv4.String() != "https://api.github.com/" && !GHECDataResidencyMatch.MatchString(uv4.String()) {
 		uv4.Path = path.Join(uv4.Path, "api/graphql/")
 	} else {
 		uv4.Path = path.Join(uv4.Path, "graphql")
 	}
 
-	return githubv4.NewEnterpriseClient(uv4.String(), client), nil
+	return uv4.String(), nil
 }
 
 func (c *Config) NewRESTClient(client *http.Client) (*github.Client, error) {
@@ -145,6 +323,10 @@ func (c *Config) ConfigureOwner(owner *Owner) (*Owner, error) {
 // https://godoc.org/github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema#ConfigureFunc
 func (c *Config) Meta() (interface{}, error) {
 
+	if err := c.initTransport(); err != nil {
+		return nil, err
+	}
+
 	var client *http.Client
 	if c.Anonymous() {
 		client = c.AnonymousHTTPClient()
@@ -162,9 +344,16 @@ func (c *Config) Meta() (interface{}, error) {
 		return nil, err
 	}
 
+	v4url, err := c.graphQLURL()
+	if err != nil {
+		return nil, err
+	}
+
 	var owner Owner
 	owner.v4client = v4client
+	owner.v4url = v4url
 	owner.v3client = v3client
+	owner.transport = c.baseTransport()
 	owner.StopContext = context.Background()
 
 	_, err = c.ConfigureOwner(&owner)
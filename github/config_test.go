@@ -2,6 +2,9 @@ package github
 
 import (
 	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/shurcooL/githubv4"
@@ -65,6 +68,72 @@ func TestGHECDataResidencyMatch(t *testing.T) {
 	}
 }
 
+func TestNewHTTPTransport(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	caCertPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw}))
+
+	testCases := []struct {
+		insecure    bool
+		caCertPEM   string
+		succeeds    bool
+		description string
+	}{
+		{
+			succeeds:    false,
+			description: "rejects an unknown certificate authority",
+		},
+		{
+			insecure:    true,
+			succeeds:    true,
+			description: "skips verification in insecure mode",
+		},
+		{
+			caCertPEM:   caCertPEM,
+			succeeds:    true,
+			description: "trusts the provided certificate authority",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			transport, err := NewHTTPTransport(tc.insecure, tc.caCertPEM)
+			if err != nil {
+				t.Fatalf("failed to create transport: %s", err)
+			}
+
+			client := &http.Client{Transport: transport}
+			resp, err := client.Get(ts.URL)
+			if err == nil {
+				resp.Body.Close()
+			}
+			if succeeded := err == nil; succeeded != tc.succeeds {
+				t.Errorf("expected success=%v, got error: %v", tc.succeeds, err)
+			}
+		})
+	}
+
+	t.Run("fails on invalid certificates", func(t *testing.T) {
+		_, err := NewHTTPTransport(false, "not a certificate")
+		if err == nil {
+			t.Fatal("expected an error for invalid CA certificates")
+		}
+	})
+
+	t.Run("fails the configuration on invalid certificates", func(t *testing.T) {
+		config := Config{BaseURL: "https://api.github.com/", CACertPEM: "not a certificate"}
+		if _, err := config.Meta(); err == nil {
+			t.Fatal("expected an error for invalid CA certificates")
+		}
+		if _, err := config.AnonymousHTTPClient().Get(ts.URL); err == nil {
+			t.Fatal("expected requests to fail with invalid CA certificates")
+		}
+	})
+}
+
 func TestAccConfigMeta(t *testing.T) {
 
 	// FIXME: Skip test runs during travis lint checking
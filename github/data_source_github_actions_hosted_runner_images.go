@@ -0,0 +1,106 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceGithubActionsHostedRunnerImages() *schema.Resource {
+	return &schema.Resource{
+		Description: "Lookup the images available for GitHub-hosted runners.",
+
+		Read: dataSourceGithubActionsHostedRunnerImagesRead,
+
+		Schema: map[string]*schema.Schema{
+			"source": {
+				Description:      "The source of the images. Must be one of 'github' or 'partner'.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "github",
+				ValidateDiagFunc: validateValueFunc([]string{"github", "partner"}),
+			},
+			"images": {
+				Description: "The available images.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Description: "The ID of the image.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"platform": {
+							Description: "The operating system and architecture of the image.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"size_gb": {
+							Description: "The size of the image in gigabytes.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"display_name": {
+							Description: "The display name of the image.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"source": {
+							Description: "The source of the image.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceGithubActionsHostedRunnerImagesRead(d *schema.ResourceData, meta interface{}) error {
+	err := checkOrganization(meta)
+	if err != nil {
+		return err
+	}
+
+	client := meta.(*Owner).v3client
+	ctx := context.Background()
+	orgName := meta.(*Owner).name
+
+	path := "github-owned"
+	if d.Get("source").(string) == "partner" {
+		path = "partner"
+	}
+
+	req, err := client.NewRequest("GET", fmt.Sprintf("orgs/%v/actions/hosted-runners/images/%v", orgName, path), nil)
+	if err != nil {
+		return err
+	}
+
+	var result struct {
+		Images []*hostedRunnerImage `json:"images"`
+	}
+	if _, err = client.Do(ctx, req, &result); err != nil {
+		return err
+	}
+
+	images := make([]any, 0, len(result.Images))
+	for _, image := range result.Images {
+		images = append(images, map[string]any{
+			"id":           image.ID,
+			"platform":     image.Platform,
+			"size_gb":      image.SizeGB,
+			"display_name": image.DisplayName,
+			"source":       image.Source,
+		})
+	}
+
+	d.SetId(fmt.Sprintf("%s/github-hosted-runner-images/%s", orgName, path))
+	if err := d.Set("images", images); err != nil {
+		return fmt.Errorf("error setting images: %s", err)
+	}
+
+	return nil
+}
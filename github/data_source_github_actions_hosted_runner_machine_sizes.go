@@ -0,0 +1,88 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceGithubActionsHostedRunnerMachineSizes() *schema.Resource {
+	return &schema.Resource{
+		Description: "Lookup the machine sizes available for GitHub-hosted runners.",
+
+		Read: dataSourceGithubActionsHostedRunnerMachineSizesRead,
+
+		Schema: map[string]*schema.Schema{
+			"machine_sizes": {
+				Description: "The available machine sizes.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Description: "The ID of the machine size, used as the 'size' of a runner.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"cpu_cores": {
+							Description: "The number of CPU cores.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"memory_gb": {
+							Description: "The memory in gigabytes.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"storage_gb": {
+							Description: "The storage in gigabytes.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceGithubActionsHostedRunnerMachineSizesRead(d *schema.ResourceData, meta interface{}) error {
+	err := checkOrganization(meta)
+	if err != nil {
+		return err
+	}
+
+	client := meta.(*Owner).v3client
+	ctx := context.Background()
+	orgName := meta.(*Owner).name
+
+	req, err := client.NewRequest("GET", fmt.Sprintf("orgs/%v/actions/hosted-runners/machine-sizes", orgName), nil)
+	if err != nil {
+		return err
+	}
+
+	var result struct {
+		MachineSpecs []*hostedRunnerMachineSize `json:"machine_specs"`
+	}
+	if _, err = client.Do(ctx, req, &result); err != nil {
+		return err
+	}
+
+	sizes := make([]any, 0, len(result.MachineSpecs))
+	for _, size := range result.MachineSpecs {
+		sizes = append(sizes, map[string]any{
+			"id":         size.ID,
+			"cpu_cores":  size.CPUCores,
+			"memory_gb":  size.MemoryGB,
+			"storage_gb": size.StorageGB,
+		})
+	}
+
+	d.SetId(fmt.Sprintf("%s/github-hosted-runner-machine-sizes", orgName))
+	if err := d.Set("machine_sizes", sizes); err != nil {
+		return fmt.Errorf("error setting machine_sizes: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceGithubActionsHostedRunnerPlatforms() *schema.Resource {
+	return &schema.Resource{
+		Description: "Lookup the platforms available for GitHub-hosted runners.",
+
+		Read: dataSourceGithubActionsHostedRunnerPlatformsRead,
+
+		Schema: map[string]*schema.Schema{
+			"platforms": {
+				Description: "The available platforms, such as 'linux-x64'.",
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceGithubActionsHostedRunnerPlatformsRead(d *schema.ResourceData, meta interface{}) error {
+	err := checkOrganization(meta)
+	if err != nil {
+		return err
+	}
+
+	client := meta.(*Owner).v3client
+	ctx := context.Background()
+	orgName := meta.(*Owner).name
+
+	req, err := client.NewRequest("GET", fmt.Sprintf("orgs/%v/actions/hosted-runners/platforms", orgName), nil)
+	if err != nil {
+		return err
+	}
+
+	var result struct {
+		Platforms []string `json:"platforms"`
+	}
+	if _, err = client.Do(ctx, req, &result); err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s/github-hosted-runner-platforms", orgName))
+	if err := d.Set("platforms", result.Platforms); err != nil {
+		return fmt.Errorf("error setting platforms: %s", err)
+	}
+
+	return nil
+}
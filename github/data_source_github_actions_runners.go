@@ -0,0 +1,188 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceGithubActionsRunners() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceGithubActionsRunnersRead,
+
+		Schema: map[string]*schema.Schema{
+			"repository": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The name of the repository to list the runners of. The runners of the organization are listed when not set.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only list the runners with this name.",
+			},
+			"status": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validateValueFunc([]string{"online", "offline"}),
+				Description:      "Only list the runners with this status. Must be one of 'online' or 'offline'.",
+			},
+			"busy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Only list the runners that are, or are not, running a job.",
+			},
+			"labels": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "Only list the runners with all of these labels.",
+			},
+			"runners": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"os": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"busy": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"labels": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceGithubActionsRunnersRead(d *schema.ResourceData, meta interface{}) error {
+	repository := d.Get("repository").(string)
+	owner := meta.(*Owner).name
+
+	client := meta.(*Owner).v3client
+	ctx := context.Background()
+
+	options := &github.ListRunnersOptions{
+		ListOptions: github.ListOptions{
+			PerPage: 100,
+		},
+	}
+	if name, ok := d.GetOk("name"); ok {
+		options.Name = github.String(name.(string))
+	}
+
+	var all []*github.Runner
+	for {
+		var runners *github.Runners
+		var resp *github.Response
+		var err error
+		if repository != "" {
+			runners, resp, err = client.Actions.ListRunners(ctx, owner, repository, options)
+		} else {
+			runners, resp, err = client.Actions.ListOrganizationRunners(ctx, owner, options)
+		}
+		if err != nil {
+			return err
+		}
+
+		all = append(all, runners.Runners...)
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		options.Page = resp.NextPage
+	}
+
+	// busy is a filter only when it is configured, as false is a filter too.
+	var busy *bool
+	if v := d.GetRawConfig().GetAttr("busy"); !v.IsNull() && v.Type().Equals(cty.Bool) {
+		busy = github.Bool(v.True())
+	}
+
+	filtered := filterRunners(all, d.Get("status").(string), busy, expandStringList(d.Get("labels").(*schema.Set).List()))
+
+	d.SetId(fmt.Sprintf("%s/%s", owner, repository))
+	err := d.Set("runners", flattenRunners(filtered))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// filterRunners returns the runners with the status, busy state and all the
+// labels given. Empty filters match every runner.
+func filterRunners(runners []*github.Runner, status string, busy *bool, labels []string) []*github.Runner {
+	filtered := make([]*github.Runner, 0, len(runners))
+	for _, runner := range runners {
+		if status != "" && runner.GetStatus() != status {
+			continue
+		}
+		if busy != nil && runner.GetBusy() != *busy {
+			continue
+		}
+
+		names := make(map[string]bool, len(runner.Labels))
+		for _, label := range runner.Labels {
+			names[label.GetName()] = true
+		}
+		hasLabels := true
+		for _, label := range labels {
+			if !names[label] {
+				hasLabels = false
+				break
+			}
+		}
+		if !hasLabels {
+			continue
+		}
+
+		filtered = append(filtered, runner)
+	}
+	return filtered
+}
+
+func flattenRunners(runners []*github.Runner) []map[string]interface{} {
+	results := make([]map[string]interface{}, 0, len(runners))
+	for _, runner := range runners {
+		labels := make([]string, 0, len(runner.Labels))
+		for _, label := range runner.Labels {
+			labels = append(labels, label.GetName())
+		}
+
+		results = append(results, map[string]interface{}{
+			"id":     runner.GetID(),
+			"name":   runner.GetName(),
+			"os":     runner.GetOS(),
+			"status": runner.GetStatus(),
+			"busy":   runner.GetBusy(),
+			"labels": labels,
+		})
+	}
+	return results
+}
@@ -0,0 +1,97 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccGithubActionsRunnersDataSource(t *testing.T) {
+
+	t.Run("queries the runners of an organization without error", func(t *testing.T) {
+
+		config := `
+			data "github_actions_runners" "test" {
+				status = "online"
+			}
+		`
+
+		check := resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttrSet("data.github_actions_runners.test", "runners.#"),
+		)
+
+		testCase := func(t *testing.T, mode string) {
+			resource.Test(t, resource.TestCase{
+				PreCheck:  func() { skipUnlessMode(t, mode) },
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: config,
+						Check:  check,
+					},
+				},
+			})
+		}
+
+		t.Run("with an anonymous account", func(t *testing.T) {
+			t.Skip("anonymous account not supported for this operation")
+		})
+
+		t.Run("with an individual account", func(t *testing.T) {
+			t.Skip("individual account not supported for this operation")
+		})
+
+		t.Run("with an organization account", func(t *testing.T) {
+			testCase(t, organization)
+		})
+	})
+}
+
+func TestFilterRunners(t *testing.T) {
+	runner := func(id int64, status string, busy bool, labels ...string) *github.Runner {
+		r := &github.Runner{ID: github.Int64(id), Status: github.String(status), Busy: github.Bool(busy)}
+		for _, label := range labels {
+			r.Labels = append(r.Labels, &github.RunnerLabels{Name: github.String(label)})
+		}
+		return r
+	}
+	runners := []*github.Runner{
+		runner(1, "online", true, "self-hosted", "linux", "gpu"),
+		runner(2, "online", false, "self-hosted", "linux"),
+		runner(3, "offline", false, "self-hosted", "windows", "gpu"),
+	}
+
+	cases := []struct {
+		name     string
+		status   string
+		busy     *bool
+		labels   []string
+		expected []int64
+	}{
+		{name: "no filter", expected: []int64{1, 2, 3}},
+		{name: "status", status: "online", expected: []int64{1, 2}},
+		{name: "not busy", busy: github.Bool(false), expected: []int64{2, 3}},
+		{name: "labels", labels: []string{"gpu", "self-hosted"}, expected: []int64{1, 3}},
+		{name: "all filters", status: "online", busy: github.Bool(false), labels: []string{"linux"}, expected: []int64{2}},
+		{name: "no match", labels: []string{"macos"}, expected: []int64{}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			filtered := filterRunners(runners, tc.status, tc.busy, tc.labels)
+			ids := make([]int64, 0, len(filtered))
+			for _, r := range filtered {
+				ids = append(ids, r.GetID())
+			}
+			if len(ids) != len(tc.expected) {
+				t.Fatalf("Expected runners %v, got %v", tc.expected, ids)
+			}
+			for i := range ids {
+				if ids[i] != tc.expected[i] {
+					t.Fatalf("Expected runners %v, got %v", tc.expected, ids)
+				}
+			}
+		})
+	}
+}
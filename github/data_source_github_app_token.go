@@ -1,6 +1,7 @@
 package github
 
 import (
+	"net/http"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -19,7 +20,7 @@ func dataSourceGithubAppToken() *schema.Resource {
 			"installation_id": {
 				Type:        schema.TypeString,
 				Required:    true,
-				Description: descriptions["app_auth.installation_id"],
+				Description: "The GitHub App installation instance ID.",
 			},
 			"pem_file": {
 				Type:        schema.TypeString,
@@ -41,7 +42,8 @@ func dataSourceGithubAppTokenRead(d *schema.ResourceData, meta interface{}) erro
 	installationID := d.Get("installation_id").(string)
 	pemFile := d.Get("pem_file").(string)
 
-	baseURL := meta.(*Owner).v3client.BaseURL.String()
+	owner := meta.(*Owner)
+	baseURL := owner.v3client.BaseURL.String()
 
 	// The Go encoding/pem package only decodes PEM formatted blocks
 	// that contain new lines. Some platforms, like Terraform Cloud,
@@ -52,7 +54,7 @@ func dataSourceGithubAppTokenRead(d *schema.ResourceData, meta interface{}) erro
 	// actual new line character before decoding.
 	pemFile = strings.Replace(pemFile, `\n`, "\n", -1)
 
-	token, err := GenerateOAuthTokenFromApp(baseURL, appID, installationID, pemFile)
+	token, err := GenerateOAuthTokenFromApp(&http.Client{Transport: owner.transport}, baseURL, appID, installationID, pemFile)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,253 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceGithubGraphQL() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceGithubGraphQLRead,
+
+		Schema: map[string]*schema.Schema{
+			"query": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The GraphQL query to run. Mutations are not allowed.",
+			},
+			"variables": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: toDiagFunc(validation.StringIsJSON, "variables"),
+				Description:      "The variables of the query, as a JSON object.",
+			},
+			"paginate_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The dot-separated path to a connection to fetch every page of, for example 'organization.repositories'. The query must then declare a '$cursor: String' variable and select the 'pageInfo { hasNextPage endCursor }' of the connection.",
+			},
+			"result": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The data returned by the query, as a JSON string.",
+			},
+		},
+	}
+}
+
+func dataSourceGithubGraphQLRead(d *schema.ResourceData, meta interface{}) error {
+	ctx := context.Background()
+
+	query := d.Get("query").(string)
+	if !isGraphQLQuery(query) {
+		return fmt.Errorf("only queries are allowed")
+	}
+
+	variables := map[string]interface{}{}
+	if v, ok := d.GetOk("variables"); ok {
+		decoder := json.NewDecoder(strings.NewReader(v.(string)))
+		decoder.UseNumber()
+		if err := decoder.Decode(&variables); err != nil {
+			return fmt.Errorf("variables must be a JSON object: %s", err)
+		}
+	}
+
+	data, err := queryGraphQL(ctx, meta.(*Owner), query, variables)
+	if err != nil {
+		return err
+	}
+
+	if path, ok := d.GetOk("paginate_path"); ok {
+		keys := strings.Split(path.(string), ".")
+		connection, err := getGraphQLConnection(data, keys)
+		if err != nil {
+			return err
+		}
+
+		for {
+			pageInfo, _ := connection["pageInfo"].(map[string]interface{})
+			if hasNextPage, _ := pageInfo["hasNextPage"].(bool); !hasNextPage {
+				break
+			}
+			variables["cursor"] = pageInfo["endCursor"]
+
+			page, err := queryGraphQL(ctx, meta.(*Owner), query, variables)
+			if err != nil {
+				return err
+			}
+			next, err := getGraphQLConnection(page, keys)
+			if err != nil {
+				return err
+			}
+
+			for _, key := range []string{"nodes", "edges"} {
+				if items, ok := next[key].([]interface{}); ok {
+					previous, _ := connection[key].([]interface{})
+					connection[key] = append(previous, items...)
+				}
+			}
+			connection["pageInfo"] = next["pageInfo"]
+		}
+	}
+
+	result, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	id := sha256.Sum256([]byte(query + d.Get("variables").(string)))
+	d.SetId(hex.EncodeToString(id[:]))
+	if err = d.Set("result", string(result)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// queryGraphQL runs a query written as a string, which the githubv4 client
+// cannot do as it builds queries from structs, with the HTTP client the
+// provider configured for it.
+func queryGraphQL(ctx context.Context, owner *Owner, query string, variables map[string]interface{}) (map[string]interface{}, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"query":     query,
+		"variables": variables,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, owner.v4url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := owner.v3client.Client().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("non-200 OK status code: %v body: %q", resp.Status, b)
+	}
+
+	var out struct {
+		Data   map[string]interface{}
+		Errors []struct {
+			Message string
+		}
+	}
+	decoder := json.NewDecoder(resp.Body)
+	decoder.UseNumber()
+	if err = decoder.Decode(&out); err != nil {
+		return nil, err
+	}
+	if len(out.Errors) > 0 {
+		messages := make([]string, 0, len(out.Errors))
+		for _, e := range out.Errors {
+			messages = append(messages, e.Message)
+		}
+		return nil, fmt.Errorf("%s", strings.Join(messages, ", "))
+	}
+
+	return out.Data, nil
+}
+
+// graphQLDefinitionKeywords returns the keyword starting each definition of
+// a GraphQL document, such as "query" or "mutation", skipping comments and
+// strings. Anonymous queries, written as a bare selection set, have none.
+func graphQLDefinitionKeywords(document string) []string {
+	var keywords []string
+	braces, parentheses := 0, 0
+	expectDefinition := true
+
+	for i := 0; i < len(document); i++ {
+		c := document[i]
+		switch {
+		case c == '#':
+			for i < len(document) && document[i] != '\n' && document[i] != '\r' {
+				i++
+			}
+		case strings.HasPrefix(document[i:], `"""`):
+			i += 3
+			for i < len(document) && !strings.HasPrefix(document[i:], `"""`) {
+				if strings.HasPrefix(document[i:], `\"""`) {
+					i += 3
+				}
+				i++
+			}
+			i += 2
+		case c == '"':
+			for i++; i < len(document) && document[i] != '"'; i++ {
+				if document[i] == '\\' {
+					i++
+				}
+			}
+		case c == '{':
+			braces++
+			expectDefinition = false
+		case c == '}':
+			braces--
+			if braces == 0 && parentheses == 0 {
+				expectDefinition = true
+			}
+		case c == '(':
+			parentheses++
+		case c == ')':
+			parentheses--
+		case c == '_' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z':
+			start := i
+			for i+1 < len(document) && isGraphQLNameChar(document[i+1]) {
+				i++
+			}
+			if expectDefinition && braces == 0 && parentheses == 0 {
+				keywords = append(keywords, document[start:i+1])
+				expectDefinition = false
+			}
+		}
+	}
+
+	return keywords
+}
+
+// isGraphQLQuery reports whether every definition of a GraphQL document is a
+// query or a fragment, so that running it cannot change anything.
+func isGraphQLQuery(document string) bool {
+	for _, keyword := range graphQLDefinitionKeywords(document) {
+		if keyword != "query" && keyword != "fragment" {
+			return false
+		}
+	}
+	return true
+}
+
+func isGraphQLNameChar(c byte) bool {
+	return c == '_' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9'
+}
+
+func getGraphQLConnection(data map[string]interface{}, keys []string) (map[string]interface{}, error) {
+	current := data
+	for _, key := range keys {
+		next, ok := current[key].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("paginate_path: no object found at %q", strings.Join(keys, "."))
+		}
+		current = next
+	}
+	if _, ok := current["pageInfo"].(map[string]interface{}); !ok {
+		return nil, fmt.Errorf("paginate_path: %q does not select pageInfo", strings.Join(keys, "."))
+	}
+	return current, nil
+}
@@ -0,0 +1,137 @@
+package github
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccGithubGraphQLDataSource(t *testing.T) {
+
+	t.Run("queries the viewer without error", func(t *testing.T) {
+
+		config := `
+			data "github_graphql" "test" {
+				query = "query { viewer { login } }"
+			}
+		`
+
+		check := resource.ComposeTestCheckFunc(
+			resource.TestMatchResourceAttr(
+				"data.github_graphql.test", "result", regexp.MustCompile(`^\{"viewer":\{"login":".+"\}\}$`),
+			),
+		)
+
+		testCase := func(t *testing.T, mode string) {
+			resource.Test(t, resource.TestCase{
+				PreCheck:  func() { skipUnlessMode(t, mode) },
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: config,
+						Check:  check,
+					},
+				},
+			})
+		}
+
+		t.Run("with an anonymous account", func(t *testing.T) {
+			t.Skip("anonymous account not supported for this operation")
+		})
+
+		t.Run("with an individual account", func(t *testing.T) {
+			testCase(t, individual)
+		})
+
+		t.Run("with an organization account", func(t *testing.T) {
+			testCase(t, organization)
+		})
+	})
+}
+
+func TestDataSourceGithubGraphQLRead(t *testing.T) {
+	query := "query($login: String!, $cursor: String) { organization(login: $login) { repositories(first: 1, after: $cursor) { nodes { name } pageInfo { hasNextPage endCursor } } } }"
+
+	ts := githubApiMock([]*mockResponse{
+		{
+			ExpectedUri:    "/graphql",
+			ExpectedMethod: "POST",
+			ExpectedBody:   []byte(fmt.Sprintf(`{"query":%q,"variables":{"login":"octo"}}`, query)),
+			ResponseBody:   `{"data": {"organization": {"repositories": {"nodes": [{"name": "hello"}], "pageInfo": {"hasNextPage": true, "endCursor": "Y3Vyc29y"}}}}}`,
+			StatusCode:     200,
+		},
+		{
+			ExpectedUri:    "/graphql",
+			ExpectedMethod: "POST",
+			ExpectedBody:   []byte(fmt.Sprintf(`{"query":%q,"variables":{"cursor":"Y3Vyc29y","login":"octo"}}`, query)),
+			ResponseBody:   `{"data": {"organization": {"repositories": {"nodes": [{"name": "world"}], "pageInfo": {"hasNextPage": false, "endCursor": "ZW5k"}}}}}`,
+			StatusCode:     200,
+		},
+	})
+	defer ts.Close()
+
+	meta := &Owner{
+		v3client: github.NewClient(nil),
+		v4url:    ts.URL + "/graphql",
+	}
+
+	d := schema.TestResourceDataRaw(t, dataSourceGithubGraphQL().Schema, map[string]interface{}{
+		"query":         query,
+		"variables":     `{"login": "octo"}`,
+		"paginate_path": "organization.repositories",
+	})
+
+	if err := dataSourceGithubGraphQLRead(d, meta); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	expected := `{"organization":{"repositories":{"nodes":[{"name":"hello"},{"name":"world"}],"pageInfo":{"endCursor":"ZW5k","hasNextPage":false}}}}`
+	if result := d.Get("result").(string); result != expected {
+		t.Errorf("Expected %s, got %s", expected, result)
+	}
+}
+
+func TestDataSourceGithubGraphQLRead_mutation(t *testing.T) {
+	queries := []string{
+		`mutation { addStar(input: {starrableId: "R_1"}) { clientMutationId } }`,
+		"# Star a repository\nmutation { addStar(input: {starrableId: \"R_1\"}) { clientMutationId } }",
+		`query A { viewer { login } } mutation B { addStar(input: {starrableId: "R_1"}) { clientMutationId } }`,
+		`subscription { viewer { login } }`,
+	}
+
+	for _, query := range queries {
+		d := schema.TestResourceDataRaw(t, dataSourceGithubGraphQL().Schema, map[string]interface{}{
+			"query": query,
+		})
+
+		if err := dataSourceGithubGraphQLRead(d, &Owner{}); err == nil {
+			t.Errorf("Expected an error for %q, got none", query)
+		}
+	}
+}
+
+func TestGraphQLDefinitionKeywords(t *testing.T) {
+	cases := []struct {
+		document string
+		expected []string
+	}{
+		{`{ viewer { login } }`, nil},
+		{`query($owner: String = "mutation {") { viewer { login } }`, []string{"query"}},
+		{"# mutation\nquery { viewer { login } }", []string{"query"}},
+		{`query { search(query: "} mutation {", type: REPOSITORY) { repositoryCount } }`, []string{"query"}},
+		{`query { viewer { ...Login } } fragment Login on User { login }`, []string{"query", "fragment"}},
+		{"\"\"\"\n} mutation\n\"\"\"\nquery { viewer { login } }", []string{"query"}},
+		{"# x\nmutation { addStar(input: {starrableId: \"R_1\"}) { clientMutationId } }", []string{"mutation"}},
+	}
+
+	for _, tc := range cases {
+		if keywords := graphQLDefinitionKeywords(tc.document); !reflect.DeepEqual(keywords, tc.expected) {
+			t.Errorf("Expected %q for %q, got %q", tc.expected, tc.document, keywords)
+		}
+	}
+}
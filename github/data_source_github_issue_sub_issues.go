@@ -0,0 +1,123 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceGithubIssueSubIssues() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceGithubIssueSubIssuesRead,
+
+		Schema: map[string]*schema.Schema{
+			"repository": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the repository of the issue.",
+			},
+			"number": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: "The number of the issue.",
+			},
+			"parent_issue_number": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of the issue this issue is a sub-issue of.",
+			},
+			"sub_issues": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The sub-issues of the issue.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"number": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"title": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"issue_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"html_url": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceGithubIssueSubIssuesRead(d *schema.ResourceData, meta interface{}) error {
+	repository := d.Get("repository").(string)
+	number := d.Get("number").(int)
+	owner := meta.(*Owner).name
+
+	client := meta.(*Owner).v3client
+	ctx := context.Background()
+
+	results := make([]map[string]interface{}, 0)
+	for page := 1; ; {
+		u := fmt.Sprintf("repos/%v/%v/issues/%d/sub_issues?per_page=%d&page=%d", owner, repository, number, maxPerPage, page)
+		req, err := client.NewRequest("GET", u, nil)
+		if err != nil {
+			return err
+		}
+
+		var subIssues []*issueWithType
+		resp, err := client.Do(ctx, req, &subIssues)
+		if err != nil {
+			return err
+		}
+
+		for _, issue := range subIssues {
+			results = append(results, map[string]interface{}{
+				"id":         issue.GetID(),
+				"number":     issue.GetNumber(),
+				"title":      issue.GetTitle(),
+				"state":      issue.GetState(),
+				"issue_type": issue.Type.GetName(),
+				"html_url":   issue.GetHTMLURL(),
+			})
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		page = resp.NextPage
+	}
+
+	parent, err := getParentIssue(ctx, client, owner, repository, number)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%d", owner, repository, number))
+	err = d.Set("sub_issues", results)
+	if err != nil {
+		return err
+	}
+	err = d.Set("parent_issue_number", parent.GetNumber())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceGithubOrganizationIssueTypes() *schema.Resource {
+	return &schema.Resource{
+		Description: "Lookup all issue types of an organization.",
+
+		Read: dataSourceGithubOrganizationIssueTypesRead,
+
+		Schema: map[string]*schema.Schema{
+			"issue_types": {
+				Description: "The issue types of the organization.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Description: "The ID of the issue type.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"node_id": {
+							Description: "The node ID of the issue type.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"name": {
+							Description: "The name of the issue type.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"description": {
+							Description: "The description of the issue type.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"color": {
+							Description: "The color of the issue type.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"is_enabled": {
+							Description: "Whether the issue type can be used in the repositories of the organization.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceGithubOrganizationIssueTypesRead(d *schema.ResourceData, meta interface{}) error {
+	err := checkOrganization(meta)
+	if err != nil {
+		return err
+	}
+
+	client := meta.(*Owner).v3client
+	ctx := context.Background()
+	orgName := meta.(*Owner).name
+
+	issueTypes, err := listOrganizationIssueTypes(ctx, client, orgName)
+	if err != nil {
+		return err
+	}
+
+	allIssueTypes := make([]any, 0, len(issueTypes))
+	for _, t := range issueTypes {
+		description, color := "", ""
+		if t.Description != nil {
+			description = *t.Description
+		}
+		if t.Color != nil {
+			color = *t.Color
+		}
+		allIssueTypes = append(allIssueTypes, map[string]any{
+			"id":          t.ID,
+			"node_id":     t.NodeID,
+			"name":        t.Name,
+			"description": description,
+			"color":       color,
+			"is_enabled":  t.IsEnabled,
+		})
+	}
+
+	d.SetId(fmt.Sprintf("%s/github-org-issue-types", orgName))
+	if err := d.Set("issue_types", allIssueTypes); err != nil {
+		return fmt.Errorf("error setting issue_types: %s", err)
+	}
+
+	return nil
+}
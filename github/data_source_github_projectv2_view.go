@@ -0,0 +1,144 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/shurcooL/githubv4"
+)
+
+// Projects V2 views cannot be created or modified through the GraphQL API, so
+// they are only exposed as a data source.
+func dataSourceGithubProjectV2View() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceGithubProjectV2ViewRead,
+
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The node ID of the project.",
+			},
+			"number": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"number", "name"},
+				Description:  "The number of the view, as found in its URL.",
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"number", "name"},
+				Description:  "The name of the view.",
+			},
+			"layout": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The layout of the view: 'TABLE_LAYOUT', 'BOARD_LAYOUT' or 'ROADMAP_LAYOUT'.",
+			},
+			"filter": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The filter applied to the view.",
+			},
+			"visible_fields": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The names of the fields visible in the view, in order.",
+			},
+		},
+	}
+}
+
+func dataSourceGithubProjectV2ViewRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Owner).v4client
+	ctx := context.Background()
+
+	projectID := d.Get("project_id").(string)
+
+	type view struct {
+		ID     githubv4.ID
+		Name   githubv4.String
+		Number githubv4.Int
+		Layout githubv4.String
+		Filter githubv4.String
+		Fields struct {
+			Nodes []struct {
+				Common struct {
+					Name githubv4.String
+				} `graphql:"... on ProjectV2FieldCommon"`
+			}
+		} `graphql:"fields(first:100)"`
+	}
+	var query struct {
+		Node struct {
+			ProjectV2 struct {
+				Views struct {
+					Nodes    []view
+					PageInfo PageInfo
+				} `graphql:"views(first:100, after:$cursor)"`
+			} `graphql:"... on ProjectV2"`
+		} `graphql:"node(id:$id)"`
+	}
+	variables := map[string]interface{}{
+		"id":     githubv4.ID(projectID),
+		"cursor": (*githubv4.String)(nil),
+	}
+
+	number, hasNumber := d.GetOk("number")
+	name := d.Get("name").(string)
+
+	var found *view
+	for found == nil {
+		if err := client.Query(ctx, &query, variables); err != nil {
+			return err
+		}
+
+		for i, v := range query.Node.ProjectV2.Views.Nodes {
+			if (hasNumber && int(v.Number) == number.(int)) || (!hasNumber && string(v.Name) == name) {
+				found = &query.Node.ProjectV2.Views.Nodes[i]
+				break
+			}
+		}
+
+		if !query.Node.ProjectV2.Views.PageInfo.HasNextPage {
+			break
+		}
+		variables["cursor"] = githubv4.NewString(query.Node.ProjectV2.Views.PageInfo.EndCursor)
+	}
+
+	if found == nil {
+		if hasNumber {
+			return fmt.Errorf("could not find view %d in project %s", number.(int), projectID)
+		}
+		return fmt.Errorf("could not find view %q in project %s", name, projectID)
+	}
+
+	fields := make([]string, 0, len(found.Fields.Nodes))
+	for _, field := range found.Fields.Nodes {
+		fields = append(fields, string(field.Common.Name))
+	}
+
+	d.SetId(fmt.Sprintf("%s", found.ID))
+	if err := d.Set("number", found.Number); err != nil {
+		return err
+	}
+	if err := d.Set("name", found.Name); err != nil {
+		return err
+	}
+	if err := d.Set("layout", found.Layout); err != nil {
+		return err
+	}
+	if err := d.Set("filter", found.Filter); err != nil {
+		return err
+	}
+	if err := d.Set("visible_fields", fields); err != nil {
+		return err
+	}
+
+	return nil
+}
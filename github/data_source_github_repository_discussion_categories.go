@@ -0,0 +1,164 @@
+package github
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/shurcooL/githubv4"
+)
+
+func dataSourceGithubRepositoryDiscussionCategories() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceGithubRepositoryDiscussionCategoriesRead,
+
+		Schema: map[string]*schema.Schema{
+			"repository": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the repository.",
+			},
+			"categories": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"slug": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"emoji": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"is_answerable": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"pinned_discussions": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"number": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"title": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"url": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"category": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceGithubRepositoryDiscussionCategoriesRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Owner).v4client
+	orgName := meta.(*Owner).name
+	repoName := d.Get("repository").(string)
+
+	var query struct {
+		Repository struct {
+			ID                   githubv4.String
+			DiscussionCategories struct {
+				Nodes []struct {
+					ID           githubv4.String
+					Name         githubv4.String
+					Slug         githubv4.String
+					Emoji        githubv4.String
+					Description  githubv4.String
+					IsAnswerable githubv4.Boolean
+				}
+				PageInfo PageInfo
+			} `graphql:"discussionCategories(first:$first, after:$cursor)"`
+			PinnedDiscussions struct {
+				Nodes []struct {
+					Discussion struct {
+						Number   githubv4.Int
+						Title    githubv4.String
+						URL      githubv4.URI `graphql:"url"`
+						Category struct {
+							Name githubv4.String
+						}
+					}
+				}
+			} `graphql:"pinnedDiscussions(first:$first)"`
+		} `graphql:"repository(name: $name, owner: $owner)"`
+	}
+	variables := map[string]interface{}{
+		"first":  githubv4.Int(100),
+		"name":   githubv4.String(repoName),
+		"owner":  githubv4.String(orgName),
+		"cursor": (*githubv4.String)(nil),
+	}
+
+	var categories []interface{}
+	for {
+		err := client.Query(meta.(*Owner).StopContext, &query, variables)
+		if err != nil {
+			return err
+		}
+
+		for _, category := range query.Repository.DiscussionCategories.Nodes {
+			categories = append(categories, map[string]interface{}{
+				"id":            string(category.ID),
+				"name":          string(category.Name),
+				"slug":          string(category.Slug),
+				"emoji":         string(category.Emoji),
+				"description":   string(category.Description),
+				"is_answerable": bool(category.IsAnswerable),
+			})
+		}
+
+		if !query.Repository.DiscussionCategories.PageInfo.HasNextPage {
+			break
+		}
+		variables["cursor"] = githubv4.NewString(query.Repository.DiscussionCategories.PageInfo.EndCursor)
+	}
+
+	// A repository has at most four pinned discussions.
+	pinned := make([]interface{}, 0, len(query.Repository.PinnedDiscussions.Nodes))
+	for _, node := range query.Repository.PinnedDiscussions.Nodes {
+		pinned = append(pinned, map[string]interface{}{
+			"number":   int(node.Discussion.Number),
+			"title":    string(node.Discussion.Title),
+			"url":      node.Discussion.URL.String(),
+			"category": string(node.Discussion.Category.Name),
+		})
+	}
+
+	d.SetId(string(query.Repository.ID))
+	err := d.Set("categories", categories)
+	if err != nil {
+		return err
+	}
+	err = d.Set("pinned_discussions", pinned)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -3,11 +3,17 @@ package github
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
+	"net/url"
+	"regexp"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/itchyny/gojq"
 )
 
+var linkNextRegexp = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+
 func dataSourceGithubRestApi() *schema.Resource {
 	return &schema.Resource{
 		Read: dataSourceGithubRestApiRead,
@@ -18,6 +24,24 @@ func dataSourceGithubRestApi() *schema.Resource {
 				Required: true,
 				ForceNew: true,
 			},
+			"query_params": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Query parameters to add to the endpoint.",
+			},
+			"paginate": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Follow the 'next' links of the 'Link' header and merge the pages into a single body.",
+			},
+			"selector": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: toDiagFunc(validateJQSelector, "selector"),
+				Description:      "A jq expression applied to the response body. The body is then set to its result.",
+			},
 			"code": {
 				Type:     schema.TypeInt,
 				Computed: true,
@@ -39,12 +63,22 @@ func dataSourceGithubRestApi() *schema.Resource {
 }
 
 func dataSourceGithubRestApiRead(d *schema.ResourceData, meta interface{}) error {
-	u := d.Get("endpoint").(string)
+	u, err := url.Parse(d.Get("endpoint").(string))
+	if err != nil {
+		return err
+	}
+	if params, ok := d.GetOk("query_params"); ok {
+		q := u.Query()
+		for k, v := range params.(map[string]interface{}) {
+			q.Set(k, v.(string))
+		}
+		u.RawQuery = q.Encode()
+	}
 
 	client := meta.(*Owner).v3client
 	ctx := context.Background()
 
-	req, err := client.NewRequest("GET", u, nil)
+	req, err := client.NewRequest("GET", u.String(), nil)
 	if err != nil {
 		return err
 	}
@@ -64,6 +98,35 @@ func dataSourceGithubRestApiRead(d *schema.ResourceData, meta interface{}) error
 		return err
 	}
 
+	if d.Get("paginate").(bool) {
+		pages := [][]byte{b}
+		pageResp := resp
+		for next := getLinkNext(pageResp.Header.Get("Link")); next != ""; next = getLinkNext(pageResp.Header.Get("Link")) {
+			req, err := client.NewRequest("GET", next, nil)
+			if err != nil {
+				return err
+			}
+			if pageResp, err = client.Do(ctx, req, nil); err != nil {
+				return err
+			}
+			page, err := io.ReadAll(pageResp.Body)
+			if err != nil {
+				return err
+			}
+			pages = append(pages, page)
+		}
+
+		if b, err = mergeRestApiPages(pages); err != nil {
+			return err
+		}
+	}
+
+	if selector, ok := d.GetOk("selector"); ok {
+		if b, err = applyJQSelector(selector.(string), b); err != nil {
+			return err
+		}
+	}
+
 	d.SetId(resp.Header.Get("x-github-request-id"))
 	if err = d.Set("code", resp.StatusCode); err != nil {
 		return err
@@ -79,3 +142,93 @@ func dataSourceGithubRestApiRead(d *schema.ResourceData, meta interface{}) error
 	}
 	return nil
 }
+
+func getLinkNext(link string) string {
+	if match := linkNextRegexp.FindStringSubmatch(link); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
+// mergeRestApiPages concatenates the pages of a list. Endpoints wrapping the
+// list in an object, such as {"total_count": 2, "workflows": [...]}, have the
+// arrays of the object concatenated instead, the other fields being taken from
+// the first page.
+func mergeRestApiPages(pages [][]byte) ([]byte, error) {
+	var merged interface{}
+	for i, page := range pages {
+		var value interface{}
+		if err := json.Unmarshal(page, &value); err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			merged = value
+			continue
+		}
+
+		switch m := merged.(type) {
+		case []interface{}:
+			items, ok := value.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("page %d is not a list like the first page", i+1)
+			}
+			merged = append(m, items...)
+		case map[string]interface{}:
+			object, ok := value.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("page %d is not an object like the first page", i+1)
+			}
+			for k, v := range object {
+				items, ok := v.([]interface{})
+				if !ok {
+					continue
+				}
+				previous, _ := m[k].([]interface{})
+				m[k] = append(previous, items...)
+			}
+		default:
+			return nil, fmt.Errorf("only lists and objects can be paginated")
+		}
+	}
+
+	return json.Marshal(merged)
+}
+
+// applyJQSelector runs a jq expression on a JSON document. A single result is
+// returned as is and several results as a list.
+func applyJQSelector(selector string, body []byte) ([]byte, error) {
+	query, err := gojq.Parse(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	var input interface{}
+	if err = json.Unmarshal(body, &input); err != nil {
+		return nil, err
+	}
+
+	results := []interface{}{}
+	iter := query.Run(input)
+	for {
+		v, ok := iter.Next()
+		if !ok {
+			break
+		}
+		if err, ok := v.(error); ok {
+			return nil, err
+		}
+		results = append(results, v)
+	}
+
+	if len(results) == 1 {
+		return json.Marshal(results[0])
+	}
+	return json.Marshal(results)
+}
+
+func validateJQSelector(v interface{}, k string) (ws []string, es []error) {
+	if _, err := gojq.Parse(v.(string)); err != nil {
+		es = append(es, fmt.Errorf("%q is not a valid jq expression: %s", k, err))
+	}
+	return
+}
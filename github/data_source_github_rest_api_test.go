@@ -197,3 +197,74 @@ func TestAccGithubRestApiDataSource(t *testing.T) {
 
 	})
 }
+
+func TestMergeRestApiPages(t *testing.T) {
+	cases := []struct {
+		name     string
+		pages    []string
+		expected string
+	}{
+		{
+			name:     "lists",
+			pages:    []string{`[{"id": 1}]`, `[{"id": 2}]`},
+			expected: `[{"id":1},{"id":2}]`,
+		},
+		{
+			name:     "wrapped lists",
+			pages:    []string{`{"total_count": 2, "workflows": [{"id": 1}]}`, `{"total_count": 2, "workflows": [{"id": 2}]}`},
+			expected: `{"total_count":2,"workflows":[{"id":1},{"id":2}]}`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pages := [][]byte{}
+			for _, page := range tc.pages {
+				pages = append(pages, []byte(page))
+			}
+
+			merged, err := mergeRestApiPages(pages)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if string(merged) != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, merged)
+			}
+		})
+	}
+}
+
+func TestApplyJQSelector(t *testing.T) {
+	body := []byte(`{"workflows": [{"name": "ci", "state": "active"}, {"name": "release", "state": "disabled_manually"}]}`)
+
+	cases := []struct {
+		selector string
+		expected string
+	}{
+		{selector: ".workflows[0].name", expected: `"ci"`},
+		{selector: ".workflows[].name", expected: `["ci","release"]`},
+		{selector: `[.workflows[] | select(.state == "active") | .name]`, expected: `["ci"]`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.selector, func(t *testing.T) {
+			selected, err := applyJQSelector(tc.selector, body)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if string(selected) != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, selected)
+			}
+		})
+	}
+}
+
+func TestGetLinkNext(t *testing.T) {
+	link := `<https://api.github.com/repositories/1/issues?page=1>; rel="prev", <https://api.github.com/repositories/1/issues?page=3>; rel="next", <https://api.github.com/repositories/1/issues?page=5>; rel="last"`
+	if next := getLinkNext(link); next != "https://api.github.com/repositories/1/issues?page=3" {
+		t.Errorf("Unexpected next link %q", next)
+	}
+	if next := getLinkNext(`<https://api.github.com/repositories/1/issues?page=1>; rel="prev"`); next != "" {
+		t.Errorf("Expected no next link, got %q", next)
+	}
+}
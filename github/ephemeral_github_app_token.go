@@ -0,0 +1,129 @@
+package github
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type githubAppTokenEphemeralResource struct {
+	data *frameworkProviderData
+}
+
+type githubAppTokenEphemeralResourceModel struct {
+	AppID          types.String `tfsdk:"app_id"`
+	InstallationID types.String `tfsdk:"installation_id"`
+	PemFile        types.String `tfsdk:"pem_file"`
+	Repositories   types.List   `tfsdk:"repositories"`
+	Permissions    types.Map    `tfsdk:"permissions"`
+	Token          types.String `tfsdk:"token"`
+	ExpiresAt      types.String `tfsdk:"expires_at"`
+}
+
+func newGithubAppTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &githubAppTokenEphemeralResource{}
+}
+
+func (r *githubAppTokenEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_app_token"
+}
+
+func (r *githubAppTokenEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Generate a GitHub App installation token which is never stored in the plan or state.",
+		Attributes: map[string]schema.Attribute{
+			"app_id": schema.StringAttribute{
+				Required:    true,
+				Description: descriptions["app_auth.id"],
+			},
+			"installation_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The GitHub App installation instance ID.",
+			},
+			"pem_file": schema.StringAttribute{
+				Required:    true,
+				Sensitive:   true,
+				Description: descriptions["app_auth.pem_file"],
+			},
+			"repositories": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "The names of the repositories the token is restricted to. Defaults to all the repositories of the installation.",
+			},
+			"permissions": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "The permissions granted to the token, e.g. `contents = \"read\"`. Defaults to all the permissions of the installation.",
+			},
+			"token": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The generated token from the credentials.",
+			},
+			"expires_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "The time the token expires at, in RFC 3339 format.",
+			},
+		},
+	}
+}
+
+func (r *githubAppTokenEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*frameworkProviderData)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", "The github_app_token ephemeral resource received unexpected provider data.")
+		return
+	}
+	r.data = data
+}
+
+func (r *githubAppTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	if r.data == nil {
+		resp.Diagnostics.AddError("Provider not configured", "The github_app_token ephemeral resource requires a configured provider.")
+		return
+	}
+
+	var model githubAppTokenEphemeralResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var scope *installationTokenScope
+	if !model.Repositories.IsNull() || !model.Permissions.IsNull() {
+		scope = &installationTokenScope{}
+		resp.Diagnostics.Append(model.Repositories.ElementsAs(ctx, &scope.Repositories, false)...)
+		resp.Diagnostics.Append(model.Permissions.ElementsAs(ctx, &scope.Permissions, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// See dataSourceGithubAppTokenRead for why \n is replaced.
+	pemFile := strings.Replace(model.PemFile.ValueString(), `\n`, "\n", -1)
+
+	appJWT, err := generateAppJWT(model.AppID.ValueString(), time.Now(), []byte(pemFile))
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to sign the GitHub App JWT", err.Error())
+		return
+	}
+
+	token, err := getScopedInstallationToken(r.data.client, r.data.baseURL, appJWT, model.InstallationID.ValueString(), scope)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to generate the GitHub App installation token", err.Error())
+		return
+	}
+
+	model.Token = types.StringValue(token.AccessToken)
+	model.ExpiresAt = types.StringValue(token.Expiry.Format(time.RFC3339))
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &model)...)
+}
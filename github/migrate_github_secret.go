@@ -0,0 +1,39 @@
+package github
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// resourceGithubSecretMigrateState migrates the state of every secret
+// resource, which all share the destroy_on_drift field. Resources created
+// before the field was added need it populated with its default value.
+func resourceGithubSecretMigrateState(v int, is *terraform.InstanceState, meta interface{}) (*terraform.InstanceState, error) {
+	switch v {
+	case 0:
+		log.Printf("[INFO] Found GitHub Secret State v0; migrating to v1")
+		return migrateGithubSecretStateV0toV1(is)
+	default:
+		return is, fmt.Errorf("unexpected schema version: %d", v)
+	}
+}
+
+func migrateGithubSecretStateV0toV1(is *terraform.InstanceState) (*terraform.InstanceState, error) {
+	if is.Empty() {
+		log.Printf("[DEBUG] Empty InstanceState; nothing to migrate.")
+		return is, nil
+	}
+
+	log.Printf("[DEBUG] GitHub Secret Attributes before migration: %#v", is.Attributes)
+
+	// Add the destroy_on_drift field with default value true if it doesn't exist
+	if _, ok := is.Attributes["destroy_on_drift"]; !ok {
+		is.Attributes["destroy_on_drift"] = "true"
+	}
+
+	log.Printf("[DEBUG] GitHub Secret Attributes after State Migration: %#v", is.Attributes)
+
+	return is, nil
+}
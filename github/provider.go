@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/http"
 	"net/url"
 	"os"
 	"os/exec"
@@ -50,6 +51,46 @@ func Provider() *schema.Provider {
 				Default:     3,
 				Description: descriptions["max_retries"],
 			},
+			"retry": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: descriptions["retry"],
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"max_delay_ms": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     int(defaultRetryMaxDelay / time.Millisecond),
+							Description: descriptions["retry.max_delay_ms"],
+						},
+						"multiplier": {
+							Type:        schema.TypeFloat,
+							Optional:    true,
+							Default:     defaultRetryMultiplier,
+							Description: descriptions["retry.multiplier"],
+						},
+						"jitter": {
+							Type:        schema.TypeFloat,
+							Optional:    true,
+							Default:     defaultRetryJitter,
+							Description: descriptions["retry.jitter"],
+						},
+						"timeout_ms": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     0,
+							Description: descriptions["retry.timeout_ms"],
+						},
+						"retry_non_idempotent": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: descriptions["retry.retry_non_idempotent"],
+						},
+					},
+				},
+			},
 			"organization": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -69,6 +110,20 @@ func Provider() *schema.Provider {
 				Default:     false,
 				Description: descriptions["insecure"],
 			},
+			"ca_cert_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("GITHUB_CA_CERT_FILE", nil),
+				Description:   descriptions["ca_cert_file"],
+				ConflictsWith: []string{"ca_cert_pem"},
+			},
+			"ca_cert_pem": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("GITHUB_CA_CERT_PEM", nil),
+				Description:   descriptions["ca_cert_pem"],
+				ConflictsWith: []string{"ca_cert_file"},
+			},
 			"write_delay_ms": {
 				Type:        schema.TypeInt,
 				Optional:    true,
@@ -93,6 +148,30 @@ func Provider() *schema.Provider {
 				Default:     false,
 				Description: descriptions["parallel_requests"],
 			},
+			"http_cache": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: descriptions["http_cache"],
+			},
+			"http_cache_dir": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("GITHUB_HTTP_CACHE_DIR", nil),
+				Description: descriptions["http_cache_dir"],
+			},
+			"api_usage_report_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("GITHUB_API_USAGE_REPORT_PATH", nil),
+				Description: descriptions["api_usage_report_path"],
+			},
+			"max_concurrent_requests": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     defaultMaxConcurrency,
+				Description: descriptions["max_concurrent_requests"],
+			},
 			"app_auth": {
 				Type:        schema.TypeList,
 				Optional:    true,
@@ -108,7 +187,7 @@ func Provider() *schema.Provider {
 						},
 						"installation_id": {
 							Type:        schema.TypeString,
-							Required:    true,
+							Optional:    true,
 							DefaultFunc: schema.EnvDefaultFunc("GITHUB_APP_INSTALLATION_ID", nil),
 							Description: descriptions["app_auth.installation_id"],
 						},
@@ -135,18 +214,25 @@ func Provider() *schema.Provider {
 			"github_enterprise_actions_permissions":                                 resourceGithubActionsEnterprisePermissions(),
 			"github_actions_environment_secret":                                     resourceGithubActionsEnvironmentSecret(),
 			"github_actions_environment_variable":                                   resourceGithubActionsEnvironmentVariable(),
+			"github_actions_hosted_runner":                                          resourceGithubActionsHostedRunner(),
+			"github_actions_organization_oidc_custom_property_claim":                resourceGithubActionsOrganizationOIDCCustomPropertyClaim(),
 			"github_actions_organization_oidc_subject_claim_customization_template": resourceGithubActionsOrganizationOIDCSubjectClaimCustomizationTemplate(),
 			"github_actions_organization_permissions":                               resourceGithubActionsOrganizationPermissions(),
 			"github_actions_organization_secret":                                    resourceGithubActionsOrganizationSecret(),
 			"github_actions_organization_variable":                                  resourceGithubActionsOrganizationVariable(),
 			"github_actions_organization_secret_repositories":                       resourceGithubActionsOrganizationSecretRepositories(),
 			"github_actions_organization_secret_repository":                         resourceGithubActionsOrganizationSecretRepository(),
+			"github_actions_organization_settings":                                  resourceGithubActionsOrganizationSettings(),
 			"github_actions_repository_access_level":                                resourceGithubActionsRepositoryAccessLevel(),
 			"github_actions_repository_oidc_subject_claim_customization_template":   resourceGithubActionsRepositoryOIDCSubjectClaimCustomizationTemplate(),
 			"github_actions_repository_permissions":                                 resourceGithubActionsRepositoryPermissions(),
+			"github_actions_repository_settings":                                    resourceGithubActionsRepositorySettings(),
 			"github_actions_runner_group":                                           resourceGithubActionsRunnerGroup(),
+			"github_actions_runner_group_runner":                                    resourceGithubActionsRunnerGroupRunner(),
+			"github_actions_runner_labels":                                          resourceGithubActionsRunnerLabels(),
 			"github_actions_secret":                                                 resourceGithubActionsSecret(),
 			"github_actions_variable":                                               resourceGithubActionsVariable(),
+			"github_actions_workflow":                                               resourceGithubActionsWorkflow(),
 			"github_app_installation_repositories":                                  resourceGithubAppInstallationRepositories(),
 			"github_app_installation_repository":                                    resourceGithubAppInstallationRepository(),
 			"github_branch":                                                         resourceGithubBranch(),
@@ -166,8 +252,11 @@ func Provider() *schema.Provider {
 			"github_issue_labels":                                                   resourceGithubIssueLabels(),
 			"github_membership":                                                     resourceGithubMembership(),
 			"github_organization_block":                                             resourceOrganizationBlock(),
+			"github_organization_code_security_configuration":                       resourceGithubOrganizationCodeSecurityConfiguration(),
+			"github_organization_code_security_configuration_attachment":            resourceGithubOrganizationCodeSecurityConfigurationAttachment(),
 			"github_organization_custom_role":                                       resourceGithubOrganizationCustomRole(),
 			"github_organization_custom_properties":                                 resourceGithubOrganizationCustomProperties(),
+			"github_organization_issue_type":                                        resourceGithubOrganizationIssueType(),
 			"github_organization_project":                                           resourceGithubOrganizationProject(),
 			"github_organization_repository_role":                                   resourceGithubOrganizationRepositoryRole(),
 			"github_organization_role":                                              resourceGithubOrganizationRole(),
@@ -175,15 +264,20 @@ func Provider() *schema.Provider {
 			"github_organization_role_user":                                         resourceGithubOrganizationRoleUser(),
 			"github_organization_role_team_assignment":                              resourceGithubOrganizationRoleTeamAssignment(),
 			"github_organization_ruleset":                                           resourceGithubOrganizationRuleset(),
+			"github_organization_secret_scanning_custom_pattern_push_protection":    resourceGithubOrganizationSecretScanningCustomPatternPushProtection(),
 			"github_organization_security_manager":                                  resourceGithubOrganizationSecurityManager(),
 			"github_organization_settings":                                          resourceGithubOrganizationSettings(),
 			"github_organization_webhook":                                           resourceGithubOrganizationWebhook(),
 			"github_project_card":                                                   resourceGithubProjectCard(),
 			"github_project_column":                                                 resourceGithubProjectColumn(),
+			"github_projectv2":                                                      resourceGithubProjectV2(),
+			"github_projectv2_field":                                                resourceGithubProjectV2Field(),
+			"github_projectv2_item":                                                 resourceGithubProjectV2Item(),
 			"github_release":                                                        resourceGithubRelease(),
 			"github_repository":                                                     resourceGithubRepository(),
 			"github_repository_autolink_reference":                                  resourceGithubRepositoryAutolinkReference(),
 			"github_repository_dependabot_security_updates":                         resourceGithubRepositoryDependabotSecurityUpdates(),
+			"github_repository_code_scanning_default_setup":                         resourceGithubRepositoryCodeScanningDefaultSetup(),
 			"github_repository_collaborator":                                        resourceGithubRepositoryCollaborator(),
 			"github_repository_collaborators":                                       resourceGithubRepositoryCollaborators(),
 			"github_repository_custom_property":                                     resourceGithubRepositoryCustomProperty(),
@@ -192,6 +286,7 @@ func Provider() *schema.Provider {
 			"github_repository_environment":                                         resourceGithubRepositoryEnvironment(),
 			"github_repository_environment_deployment_policy":                       resourceGithubRepositoryEnvironmentDeploymentPolicy(),
 			"github_repository_file":                                                resourceGithubRepositoryFile(),
+			"github_repository_files":                                               resourceGithubRepositoryFiles(),
 			"github_repository_milestone":                                           resourceGithubRepositoryMilestone(),
 			"github_repository_project":                                             resourceGithubRepositoryProject(),
 			"github_repository_pull_request":                                        resourceGithubRepositoryPullRequest(),
@@ -218,6 +313,9 @@ func Provider() *schema.Provider {
 			"github_actions_environment_public_key":                                 dataSourceGithubActionsEnvironmentPublicKey(),
 			"github_actions_environment_secrets":                                    dataSourceGithubActionsEnvironmentSecrets(),
 			"github_actions_environment_variables":                                  dataSourceGithubActionsEnvironmentVariables(),
+			"github_actions_hosted_runner_images":                                   dataSourceGithubActionsHostedRunnerImages(),
+			"github_actions_hosted_runner_machine_sizes":                            dataSourceGithubActionsHostedRunnerMachineSizes(),
+			"github_actions_hosted_runner_platforms":                                dataSourceGithubActionsHostedRunnerPlatforms(),
 			"github_actions_organization_oidc_subject_claim_customization_template": dataSourceGithubActionsOrganizationOIDCSubjectClaimCustomizationTemplate(),
 			"github_actions_organization_public_key":                                dataSourceGithubActionsOrganizationPublicKey(),
 			"github_actions_organization_registration_token":                        dataSourceGithubActionsOrganizationRegistrationToken(),
@@ -226,6 +324,7 @@ func Provider() *schema.Provider {
 			"github_actions_public_key":                                             dataSourceGithubActionsPublicKey(),
 			"github_actions_registration_token":                                     dataSourceGithubActionsRegistrationToken(),
 			"github_actions_repository_oidc_subject_claim_customization_template":   dataSourceGithubActionsRepositoryOIDCSubjectClaimCustomizationTemplate(),
+			"github_actions_runners":                                                dataSourceGithubActionsRunners(),
 			"github_actions_secrets":                                                dataSourceGithubActionsSecrets(),
 			"github_actions_variables":                                              dataSourceGithubActionsVariables(),
 			"github_app":                                                            dataSourceGithubApp(),
@@ -244,14 +343,17 @@ func Provider() *schema.Provider {
 			"github_dependabot_public_key":                                          dataSourceGithubDependabotPublicKey(),
 			"github_dependabot_secrets":                                             dataSourceGithubDependabotSecrets(),
 			"github_external_groups":                                                dataSourceGithubExternalGroups(),
+			"github_graphql":                                                        dataSourceGithubGraphQL(),
 			"github_ip_ranges":                                                      dataSourceGithubIpRanges(),
 			"github_issue_labels":                                                   dataSourceGithubIssueLabels(),
+			"github_issue_sub_issues":                                               dataSourceGithubIssueSubIssues(),
 			"github_membership":                                                     dataSourceGithubMembership(),
 			"github_organization":                                                   dataSourceGithubOrganization(),
 			"github_organization_custom_role":                                       dataSourceGithubOrganizationCustomRole(),
 			"github_organization_custom_properties":                                 dataSourceGithubOrganizationCustomProperties(),
 			"github_organization_external_identities":                               dataSourceGithubOrganizationExternalIdentities(),
 			"github_organization_ip_allow_list":                                     dataSourceGithubOrganizationIpAllowList(),
+			"github_organization_issue_types":                                       dataSourceGithubOrganizationIssueTypes(),
 			"github_organization_repository_role":                                   dataSourceGithubOrganizationRepositoryRole(),
 			"github_organization_repository_roles":                                  dataSourceGithubOrganizationRepositoryRoles(),
 			"github_organization_role":                                              dataSourceGithubOrganizationRole(),
@@ -262,6 +364,7 @@ func Provider() *schema.Provider {
 			"github_organization_team_sync_groups":                                  dataSourceGithubOrganizationTeamSyncGroups(),
 			"github_organization_teams":                                             dataSourceGithubOrganizationTeams(),
 			"github_organization_webhooks":                                          dataSourceGithubOrganizationWebhooks(),
+			"github_projectv2_view":                                                 dataSourceGithubProjectV2View(),
 			"github_ref":                                                            dataSourceGithubRef(),
 			"github_release":                                                        dataSourceGithubRelease(),
 			"github_repositories":                                                   dataSourceGithubRepositories(),
@@ -272,6 +375,7 @@ func Provider() *schema.Provider {
 			"github_repository_environments":                                        dataSourceGithubRepositoryEnvironments(),
 			"github_repository_deploy_keys":                                         dataSourceGithubRepositoryDeployKeys(),
 			"github_repository_deployment_branch_policies":                          dataSourceGithubRepositoryDeploymentBranchPolicies(),
+			"github_repository_discussion_categories":                               dataSourceGithubRepositoryDiscussionCategories(),
 			"github_repository_file":                                                dataSourceGithubRepositoryFile(),
 			"github_repository_milestone":                                           dataSourceGithubRepositoryMilestone(),
 			"github_repository_pull_request":                                        dataSourceGithubRepositoryPullRequest(),
@@ -303,7 +407,13 @@ func init() {
 
 		"base_url": "The GitHub Base API URL",
 
-		"insecure": "Enable `insecure` mode for testing purposes",
+		"insecure": "Enable `insecure` mode for testing purposes. Disables TLS certificate verification.",
+
+		"ca_cert_file": "Path to a PEM encoded file of CA certificates to trust in addition to the system roots. " +
+			"Conflicts with `ca_cert_pem`.",
+
+		"ca_cert_pem": "PEM encoded CA certificates to trust in addition to the system roots. " +
+			"Conflicts with `ca_cert_file`.",
 
 		"owner": "The GitHub owner name to manage. " +
 			"Use this field instead of `organization` when managing individual accounts.",
@@ -313,9 +423,10 @@ func init() {
 
 		"app_auth": "The GitHub App credentials used to connect to GitHub. Conflicts with " +
 			"`token`. Anonymous mode is enabled if both `token` and `app_auth` are not set.",
-		"app_auth.id":              "The GitHub App ID.",
-		"app_auth.installation_id": "The GitHub App installation instance ID.",
-		"app_auth.pem_file":        "The GitHub App PEM file contents.",
+		"app_auth.id": "The GitHub App ID.",
+		"app_auth.installation_id": "The GitHub App installation instance ID. " +
+			"When not set, the installation for `owner` is looked up using the App credentials.",
+		"app_auth.pem_file": "The GitHub App PEM file contents.",
 		"write_delay_ms": "Amount of time in milliseconds to sleep in between writes to GitHub API. " +
 			"Defaults to 1000ms or 1s if not set.",
 		"read_delay_ms": "Amount of time in milliseconds to sleep in between non-write requests to GitHub API. " +
@@ -327,10 +438,36 @@ func init() {
 			"While it is possible to enable this setting on github.com, " +
 			"github.com's best practices recommend using serialization to avoid hitting abuse rate limits" +
 			"Defaults to false if not set",
+		"http_cache": "Cache responses to GET requests and revalidate them with conditional requests, " +
+			"which do not count against the rate limit when the response is unchanged. " +
+			"Up to 64MB of responses are held in memory. Defaults to false if not set.",
+		"http_cache_dir": "Directory to persist cached responses to, so they can be reused by subsequent runs. " +
+			"Responses are only cached in memory if not set.",
+		"api_usage_report_path": "Path of a file to write a JSON report of the API usage to when the provider exits, " +
+			"including request counts by endpoint, retries, time spent waiting on rate limits and the remaining quota. " +
+			"Provider instances sharing the path are merged into one report, which only covers one provider process; " +
+			"`{pid}` in the path is replaced by the process ID to keep the report of each process. " +
+			"A summary is always logged at INFO level.",
+		"max_concurrent_requests": "Maximum number of requests in flight when `parallel_requests` is enabled. " +
+			"Concurrency is automatically reduced as the remaining rate limit gets low or secondary rate limits are hit. " +
+			"Defaults to 10 if not set.",
 		"retryable_errors": "Allow the provider to retry after receiving an error status code, the max_retries should be set for this to work" +
 			"Defaults to [500, 502, 503, 504]",
 		"max_retries": "Number of times to retry a request after receiving an error status code" +
 			"Defaults to 3",
+		"retry": "Backoff policy applied between retries of requests that received a retryable error status code. " +
+			"The delay starts at `retry_delay_ms` and grows exponentially, unless GitHub indicates when to retry " +
+			"through the `Retry-After` or `x-ratelimit-reset` headers.",
+		"retry.max_delay_ms": "Maximum amount of time in milliseconds to sleep in between retries, " +
+			"including when GitHub indicates when to retry. Defaults to 30000ms or 30s if not set.",
+		"retry.multiplier": "Factor the delay in between retries is multiplied by after each retry. " +
+			"Defaults to 2 if not set.",
+		"retry.jitter": "Fraction of the delay in between retries to randomly add or remove, between 0 and 1. " +
+			"Defaults to 0.1 if not set.",
+		"retry.timeout_ms": "Maximum amount of time in milliseconds spent retrying a single request. " +
+			"Defaults to 0, meaning only the request's own deadline applies.",
+		"retry.retry_non_idempotent": "Whether to retry requests that are not safe to replay, such as `POST` and `PATCH` requests " +
+			"and GraphQL mutations. Defaults to true if not set.",
 		"max_per_page": "Number of items per page for pagination" +
 			"Defaults to 100",
 	}
@@ -365,6 +502,25 @@ func providerConfigure(p *schema.Provider) schema.ConfigureContextFunc {
 			owner = org
 		}
 
+		caCertPEM := d.Get("ca_cert_pem").(string)
+		if caCertFile := d.Get("ca_cert_file").(string); caCertFile != "" {
+			caCertBytes, err := os.ReadFile(caCertFile)
+			if err != nil {
+				return nil, wrapErrors([]error{fmt.Errorf("failed to read ca_cert_file: %w", err)})
+			}
+			caCertPEM = string(caCertBytes)
+		}
+
+		transport, err := NewHTTPTransport(insecure, caCertPEM)
+		if err != nil {
+			return nil, wrapErrors([]error{err})
+		}
+		if insecure {
+			log.Printf("[WARN] TLS certificate verification is disabled by the insecure argument")
+		}
+		httpClient := &http.Client{Transport: transport}
+
+		var appTokenSource *appInstallationTokenSource
 		if appAuth, ok := d.Get("app_auth").([]interface{}); ok && len(appAuth) > 0 && appAuth[0] != nil {
 			appAuthAttr := appAuth[0].(map[string]interface{})
 
@@ -376,12 +532,6 @@ func providerConfigure(p *schema.Provider) schema.ConfigureContextFunc {
 				return nil, wrapErrors([]error{fmt.Errorf("app_auth.id must be set and contain a non-empty value")})
 			}
 
-			if v, ok := appAuthAttr["installation_id"].(string); ok && v != "" {
-				appInstallationID = v
-			} else {
-				return nil, wrapErrors([]error{fmt.Errorf("app_auth.installation_id must be set and contain a non-empty value")})
-			}
-
 			if v, ok := appAuthAttr["pem_file"].(string); ok && v != "" {
 				// The Go encoding/pem package only decodes PEM formatted blocks
 				// that contain new lines. Some platforms, like Terraform Cloud,
@@ -395,12 +545,28 @@ func providerConfigure(p *schema.Provider) schema.ConfigureContextFunc {
 				return nil, wrapErrors([]error{fmt.Errorf("app_auth.pem_file must be set and contain a non-empty value")})
 			}
 
-			appToken, err := GenerateOAuthTokenFromApp(baseURL, appID, appInstallationID, appPemFile)
+			if v, ok := appAuthAttr["installation_id"].(string); ok && v != "" {
+				appInstallationID = v
+			} else if owner != "" {
+				installationID, err := GetInstallationIDForOwner(httpClient, baseURL, appID, owner, appPemFile)
+				if err != nil {
+					return nil, wrapErrors([]error{err})
+				}
+				log.Printf("[INFO] Using GitHub App installation %s discovered for owner %s", installationID, owner)
+				appInstallationID = installationID
+			} else {
+				return nil, wrapErrors([]error{fmt.Errorf("app_auth.installation_id must be set when owner is not set")})
+			}
+
+			// Mint the first token eagerly so invalid credentials are reported
+			// at configuration time; later tokens are minted on demand.
+			appTokenSource = NewAppInstallationTokenSource(httpClient, baseURL, appID, appInstallationID, appPemFile)
+			appToken, err := appTokenSource.Token()
 			if err != nil {
 				return nil, wrapErrors([]error{err})
 			}
 
-			token = appToken
+			token = appToken.AccessToken
 		}
 
 		isGithubDotCom, err := regexp.MatchString("^"+regexp.QuoteMeta("https://api.github.com"), baseURL)
@@ -428,7 +594,7 @@ func providerConfigure(p *schema.Provider) schema.ConfigureContextFunc {
 		}
 		log.Printf("[DEBUG] Setting read_delay_ms to %d", readDelay)
 
-		retryDelay := d.Get("read_delay_ms").(int)
+		retryDelay := d.Get("retry_delay_ms").(int)
 		if retryDelay < 0 {
 			return nil, diag.FromErr(fmt.Errorf("retry_delay_ms must be greater than or equal to 0ms"))
 		}
@@ -453,6 +619,12 @@ func providerConfigure(p *schema.Provider) schema.ConfigureContextFunc {
 			log.Printf("[DEBUG] Setting retriableErrors to %v", retryableErrors)
 		}
 
+		retryPolicy, err := expandRetryPolicy(d.Get("retry").([]interface{}))
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		log.Printf("[DEBUG] Setting retry policy to %+v", retryPolicy)
+
 		_maxPerPage := d.Get("max_per_page").(int)
 		if _maxPerPage <= 0 {
 			return nil, diag.FromErr(fmt.Errorf("max_per_page must be greater than than 0"))
@@ -464,17 +636,37 @@ func providerConfigure(p *schema.Provider) schema.ConfigureContextFunc {
 
 		log.Printf("[DEBUG] Setting parallel_requests to %t", parallelRequests)
 
+		maxConcurrency := d.Get("max_concurrent_requests").(int)
+		if maxConcurrency <= 0 {
+			return nil, diag.FromErr(fmt.Errorf("max_concurrent_requests must be greater than 0"))
+		}
+		log.Printf("[DEBUG] Setting max_concurrent_requests to %d", maxConcurrency)
+
+		httpCache := d.Get("http_cache").(bool)
+		httpCacheDir := d.Get("http_cache_dir").(string)
+		log.Printf("[DEBUG] Setting http_cache to %t, http_cache_dir to %q", httpCache, httpCacheDir)
+
 		config := Config{
 			Token:            token,
 			BaseURL:          baseURL,
 			Insecure:         insecure,
+			CACertPEM:        caCertPEM,
+			Transport:        transport,
 			Owner:            owner,
 			WriteDelay:       time.Duration(writeDelay) * time.Millisecond,
 			ReadDelay:        time.Duration(readDelay) * time.Millisecond,
 			RetryDelay:       time.Duration(retryDelay) * time.Millisecond,
 			RetryableErrors:  retryableErrors,
 			MaxRetries:       maxRetries,
+			Retry:            retryPolicy,
 			ParallelRequests: parallelRequests,
+			MaxConcurrency:   maxConcurrency,
+			HTTPCache:        httpCache,
+			HTTPCacheDir:     httpCacheDir,
+			usage:            newAPIUsage(d.Get("api_usage_report_path").(string)),
+		}
+		if appTokenSource != nil {
+			config.TokenSource = appTokenSource
 		}
 
 		meta, err := config.Meta()
@@ -486,6 +678,45 @@ func providerConfigure(p *schema.Provider) schema.ConfigureContextFunc {
 	}
 }
 
+func expandRetryPolicy(retry []interface{}) (RetryPolicy, error) {
+	if len(retry) == 0 || retry[0] == nil {
+		return RetryPolicy{
+			MaxDelay:   defaultRetryMaxDelay,
+			Multiplier: defaultRetryMultiplier,
+			Jitter:     defaultRetryJitter,
+		}, nil
+	}
+	retryAttr := retry[0].(map[string]interface{})
+
+	maxDelay := retryAttr["max_delay_ms"].(int)
+	if maxDelay <= 0 {
+		return RetryPolicy{}, fmt.Errorf("retry.max_delay_ms must be greater than 0ms")
+	}
+
+	multiplier := retryAttr["multiplier"].(float64)
+	if multiplier < 1 {
+		return RetryPolicy{}, fmt.Errorf("retry.multiplier must be greater than or equal to 1")
+	}
+
+	jitter := retryAttr["jitter"].(float64)
+	if jitter < 0 || jitter > 1 {
+		return RetryPolicy{}, fmt.Errorf("retry.jitter must be between 0 and 1")
+	}
+
+	timeout := retryAttr["timeout_ms"].(int)
+	if timeout < 0 {
+		return RetryPolicy{}, fmt.Errorf("retry.timeout_ms must be greater than or equal to 0ms")
+	}
+
+	return RetryPolicy{
+		MaxDelay:             time.Duration(maxDelay) * time.Millisecond,
+		Multiplier:           multiplier,
+		Jitter:               jitter,
+		Timeout:              time.Duration(timeout) * time.Millisecond,
+		DisableNonIdempotent: !retryAttr["retry_non_idempotent"].(bool),
+	}, nil
+}
+
 // See https://github.com/integrations/terraform-provider-github/issues/1822
 func tokenFromGhCli(baseURL string, isGithubDotCom bool) (string, error) {
 	ghCliPath := os.Getenv("GH_PATH")
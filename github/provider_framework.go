@@ -0,0 +1,242 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
+	sdkschema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// NewMuxProviderServer serves the SDKv2 provider returned by Provider along
+// with the plugin framework provider, which implements the features the
+// SDKv2 does not support, such as ephemeral resources.
+func NewMuxProviderServer(ctx context.Context) (func() tfprotov5.ProviderServer, error) {
+	sdkProvider := Provider()
+
+	frameworkProvider, err := NewFrameworkProvider(ctx, sdkProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	muxServer, err := tf5muxserver.NewMuxServer(ctx,
+		sdkProvider.GRPCProvider,
+		providerserver.NewProtocol5(frameworkProvider),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return muxServer.ProviderServer, nil
+}
+
+// frameworkProvider is the plugin framework half of the provider. Muxed
+// providers must declare identical provider schemas, so its schema is
+// derived from the SDKv2 provider schema.
+type frameworkProvider struct {
+	schema schema.Schema
+}
+
+// frameworkProviderData is handed to the framework resources once the
+// provider is configured.
+type frameworkProviderData struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewFrameworkProvider returns the plugin framework provider, sharing the
+// provider schema of sdkProvider.
+func NewFrameworkProvider(ctx context.Context, sdkProvider *sdkschema.Provider) (provider.Provider, error) {
+	resp, err := sdkProvider.GRPCProvider().GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		return nil, err
+	}
+	for _, diagnostic := range resp.Diagnostics {
+		if diagnostic.Severity == tfprotov5.DiagnosticSeverityError {
+			return nil, fmt.Errorf("%s: %s", diagnostic.Summary, diagnostic.Detail)
+		}
+	}
+
+	attributes, blocks, err := frameworkSchemaFromBlock(resp.Provider.Block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &frameworkProvider{
+		schema: schema.Schema{
+			Attributes:  attributes,
+			Blocks:      blocks,
+			Description: resp.Provider.Block.Description,
+		},
+	}, nil
+}
+
+func (p *frameworkProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "github"
+}
+
+func (p *frameworkProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
+	resp.Schema = p.schema
+}
+
+// Configure only reads the arguments needed by the framework resources; the
+// SDKv2 provider validates the whole configuration.
+func (p *frameworkProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	var baseURL, caCertFile, caCertPEM types.String
+	var insecure types.Bool
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("base_url"), &baseURL)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("insecure"), &insecure)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("ca_cert_file"), &caCertFile)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("ca_cert_pem"), &caCertPEM)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data := &frameworkProviderData{baseURL: baseURL.ValueString()}
+	if data.baseURL == "" {
+		data.baseURL = envOrDefault("GITHUB_BASE_URL", "https://api.github.com/")
+	}
+	if !strings.HasSuffix(data.baseURL, "/") {
+		data.baseURL += "/"
+	}
+
+	caCert := caCertPEM.ValueString()
+	if caCert == "" {
+		caCert = os.Getenv("GITHUB_CA_CERT_PEM")
+	}
+	file := caCertFile.ValueString()
+	if file == "" {
+		file = os.Getenv("GITHUB_CA_CERT_FILE")
+	}
+	if file != "" {
+		caCertBytes, err := os.ReadFile(file)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to read ca_cert_file", err.Error())
+			return
+		}
+		caCert = string(caCertBytes)
+	}
+
+	transport, err := NewHTTPTransport(insecure.ValueBool(), caCert)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to configure the HTTP transport", err.Error())
+		return
+	}
+	data.client = &http.Client{Transport: transport}
+
+	resp.EphemeralResourceData = data
+}
+
+func (p *frameworkProvider) Resources(ctx context.Context) []func() resource.Resource {
+	return nil
+}
+
+func (p *frameworkProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+func (p *frameworkProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		newGithubAppTokenEphemeralResource,
+	}
+}
+
+func envOrDefault(key string, defaultValue string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return defaultValue
+}
+
+// frameworkSchemaFromBlock converts a provider schema block, as served by
+// the SDKv2 provider, to plugin framework attributes and blocks.
+func frameworkSchemaFromBlock(block *tfprotov5.SchemaBlock) (map[string]schema.Attribute, map[string]schema.Block, error) {
+	attributes := make(map[string]schema.Attribute, len(block.Attributes))
+	for _, a := range block.Attributes {
+		attribute, err := frameworkSchemaAttribute(a)
+		if err != nil {
+			return nil, nil, fmt.Errorf("attribute %q: %w", a.Name, err)
+		}
+		attributes[a.Name] = attribute
+	}
+
+	blocks := make(map[string]schema.Block, len(block.BlockTypes))
+	for _, b := range block.BlockTypes {
+		if b.Nesting != tfprotov5.SchemaNestedBlockNestingModeList {
+			return nil, nil, fmt.Errorf("block %q: unsupported nesting mode %s", b.TypeName, b.Nesting)
+		}
+
+		nestedAttributes, nestedBlocks, err := frameworkSchemaFromBlock(b.Block)
+		if err != nil {
+			return nil, nil, fmt.Errorf("block %q: %w", b.TypeName, err)
+		}
+		blocks[b.TypeName] = schema.ListNestedBlock{
+			NestedObject: schema.NestedBlockObject{
+				Attributes: nestedAttributes,
+				Blocks:     nestedBlocks,
+			},
+			Description:        b.Block.Description,
+			DeprecationMessage: deprecationMessage(b.Block.Deprecated),
+		}
+	}
+
+	return attributes, blocks, nil
+}
+
+func frameworkSchemaAttribute(a *tfprotov5.SchemaAttribute) (schema.Attribute, error) {
+	deprecation := deprecationMessage(a.Deprecated)
+
+	switch {
+	case a.Type.Is(tftypes.String):
+		return schema.StringAttribute{Optional: a.Optional, Required: a.Required, Sensitive: a.Sensitive, Description: a.Description, DeprecationMessage: deprecation}, nil
+	case a.Type.Is(tftypes.Bool):
+		return schema.BoolAttribute{Optional: a.Optional, Required: a.Required, Sensitive: a.Sensitive, Description: a.Description, DeprecationMessage: deprecation}, nil
+	case a.Type.Is(tftypes.Number):
+		return schema.NumberAttribute{Optional: a.Optional, Required: a.Required, Sensitive: a.Sensitive, Description: a.Description, DeprecationMessage: deprecation}, nil
+	case a.Type.Is(tftypes.List{}):
+		elementType, err := frameworkElementType(a.Type.(tftypes.List).ElementType)
+		if err != nil {
+			return nil, err
+		}
+		return schema.ListAttribute{ElementType: elementType, Optional: a.Optional, Required: a.Required, Sensitive: a.Sensitive, Description: a.Description, DeprecationMessage: deprecation}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported type %s", a.Type)
+}
+
+func frameworkElementType(t tftypes.Type) (attr.Type, error) {
+	switch {
+	case t.Is(tftypes.String):
+		return types.StringType, nil
+	case t.Is(tftypes.Bool):
+		return types.BoolType, nil
+	case t.Is(tftypes.Number):
+		return types.NumberType, nil
+	}
+	return nil, fmt.Errorf("unsupported element type %s", t)
+}
+
+// deprecationMessage returns a message marking an attribute as deprecated
+// in the framework schema. Only the flag is part of the protocol schema, the
+// SDKv2 provider reports the actual message.
+func deprecationMessage(deprecated bool) string {
+	if deprecated {
+		return "Deprecated"
+	}
+	return ""
+}
@@ -0,0 +1,34 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+)
+
+func TestNewMuxProviderServer(t *testing.T) {
+	ctx := context.Background()
+
+	providerServer, err := NewMuxProviderServer(ctx)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	resp, err := providerServer().GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	for _, diagnostic := range resp.Diagnostics {
+		if diagnostic.Severity == tfprotov5.DiagnosticSeverityError {
+			t.Fatalf("%s: %s", diagnostic.Summary, diagnostic.Detail)
+		}
+	}
+
+	if _, ok := resp.ResourceSchemas["github_repository"]; !ok {
+		t.Error("expected the SDKv2 resources to be served")
+	}
+	if _, ok := resp.EphemeralResourceSchemas["github_app_token"]; !ok {
+		t.Error("expected the github_app_token ephemeral resource to be served")
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/google/go-github/v67/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 // checkRepositoryBranchExists tests if a branch exists in a repository.
@@ -26,6 +27,39 @@ func checkRepositoryBranchExists(client *github.Client, owner, repo, branch stri
 	return nil
 }
 
+// createRepositoryBranchIfMissing creates a branch from the
+// autocreate_branch_source_sha, or the tip of autocreate_branch_source_branch,
+// when it does not exist and autocreate_branch is set.
+func createRepositoryBranchIfMissing(ctx context.Context, d *schema.ResourceData, client *github.Client, owner, repo, branch string) error {
+	err := checkRepositoryBranchExists(client, owner, repo, branch)
+	if err == nil {
+		return nil
+	}
+	if !d.Get("autocreate_branch").(bool) {
+		return err
+	}
+
+	branchRefName := "refs/heads/" + branch
+	sourceBranchRefName := "refs/heads/" + d.Get("autocreate_branch_source_branch").(string)
+
+	if _, hasSourceSHA := d.GetOk("autocreate_branch_source_sha"); !hasSourceSHA {
+		ref, _, err := client.Git.GetRef(ctx, owner, repo, sourceBranchRefName)
+		if err != nil {
+			return fmt.Errorf("error querying GitHub branch reference %s/%s (%s): %s",
+				owner, repo, sourceBranchRefName, err)
+		}
+		if err = d.Set("autocreate_branch_source_sha", ref.Object.GetSHA()); err != nil {
+			return err
+		}
+	}
+	sourceBranchSHA := d.Get("autocreate_branch_source_sha").(string)
+	_, _, err = client.Git.CreateRef(ctx, owner, repo, &github.Reference{
+		Ref:    &branchRefName,
+		Object: &github.GitObject{SHA: &sourceBranchSHA},
+	})
+	return err
+}
+
 func getFileCommit(client *github.Client, owner, repo, file, branch string) (*github.RepositoryCommit, error) {
 	ctx := context.WithValue(context.Background(), ctxId, fmt.Sprintf("%s/%s", repo, file))
 	opts := &github.CommitsListOptions{
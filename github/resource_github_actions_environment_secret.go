@@ -2,7 +2,6 @@ package github
 
 import (
 	"context"
-	"encoding/base64"
 	"log"
 	"net/http"
 	"net/url"
@@ -16,8 +15,12 @@ func resourceGithubActionsEnvironmentSecret() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceGithubActionsEnvironmentSecretCreateOrUpdate,
 		Read:   resourceGithubActionsEnvironmentSecretRead,
+		Update: resourceGithubActionsEnvironmentSecretCreateOrUpdate,
 		Delete: resourceGithubActionsEnvironmentSecretDelete,
 
+		SchemaVersion: 1,
+		MigrateState:  resourceGithubSecretMigrateState,
+
 		Schema: map[string]*schema.Schema{
 			"repository": {
 				Type:        schema.TypeString,
@@ -44,7 +47,7 @@ func resourceGithubActionsEnvironmentSecret() *schema.Resource {
 				ForceNew:         true,
 				Sensitive:        true,
 				Description:      "Encrypted value of the secret using the GitHub public key in Base64 format.",
-				ConflictsWith:    []string{"plaintext_value"},
+				ConflictsWith:    []string{"plaintext_value", "encrypted_value_wo", "plaintext_value_wo"},
 				ValidateDiagFunc: toDiagFunc(validation.StringIsBase64, "encrypted_value"),
 			},
 			"plaintext_value": {
@@ -53,7 +56,29 @@ func resourceGithubActionsEnvironmentSecret() *schema.Resource {
 				ForceNew:      true,
 				Sensitive:     true,
 				Description:   "Plaintext value of the secret to be encrypted.",
-				ConflictsWith: []string{"encrypted_value"},
+				ConflictsWith: []string{"encrypted_value", "encrypted_value_wo", "plaintext_value_wo"},
+			},
+			"encrypted_value_wo": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Sensitive:        true,
+				WriteOnly:        true,
+				ConflictsWith:    []string{"encrypted_value", "plaintext_value", "plaintext_value_wo"},
+				Description:      "Write-only encrypted value of the secret using the GitHub public key in Base64 format. It is never stored in the state.",
+				ValidateDiagFunc: toDiagFunc(validation.StringIsBase64, "encrypted_value_wo"),
+			},
+			"plaintext_value_wo": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				WriteOnly:     true,
+				ConflictsWith: []string{"encrypted_value", "plaintext_value", "encrypted_value_wo"},
+				Description:   "Write-only plaintext value of the secret to be encrypted. It is never stored in the state.",
+			},
+			"value_wo_version": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Version of the write-only value. Write-only values are not part of the plan, so change this to upload a new value.",
 			},
 			"created_at": {
 				Type:        schema.TypeString,
@@ -65,6 +90,12 @@ func resourceGithubActionsEnvironmentSecret() *schema.Resource {
 				Computed:    true,
 				Description: "Date of 'actions_environment_secret' update.",
 			},
+			"destroy_on_drift": {
+				Type:        schema.TypeBool,
+				Default:     true,
+				Optional:    true,
+				Description: "Boolean indicating whether to recreate the secret if it's modified outside of Terraform. When `true` (default), Terraform will delete and recreate the secret if it detects external changes. When `false`, Terraform will acknowledge external changes but not recreate the secret.",
+			},
 		},
 	}
 }
@@ -78,8 +109,6 @@ func resourceGithubActionsEnvironmentSecretCreateOrUpdate(d *schema.ResourceData
 	envName := d.Get("environment").(string)
 	escapedEnvName := url.PathEscape(envName)
 	secretName := d.Get("secret_name").(string)
-	plaintextValue := d.Get("plaintext_value").(string)
-	var encryptedValue string
 
 	repo, _, err := client.Repositories.Get(ctx, owner, repoName)
 	if err != nil {
@@ -91,14 +120,9 @@ func resourceGithubActionsEnvironmentSecretCreateOrUpdate(d *schema.ResourceData
 		return err
 	}
 
-	if encryptedText, ok := d.GetOk("encrypted_value"); ok {
-		encryptedValue = encryptedText.(string)
-	} else {
-		encryptedBytes, err := encryptPlaintext(plaintextValue, publicKey)
-		if err != nil {
-			return err
-		}
-		encryptedValue = base64.StdEncoding.EncodeToString(encryptedBytes)
+	encryptedValue, err := getSecretEncryptedValue(d, publicKey)
+	if err != nil {
+		return err
 	}
 
 	// Create an EncryptedSecret and encrypt the plaintext value into it
@@ -164,30 +188,7 @@ func resourceGithubActionsEnvironmentSecretRead(d *schema.ResourceData, meta int
 		return err
 	}
 
-	// This is a drift detection mechanism based on timestamps.
-	//
-	// If we do not currently store the "updated_at" field, it means we've only
-	// just created the resource and the value is most likely what we want it to
-	// be.
-	//
-	// If the resource is changed externally in the meantime then reading back
-	// the last update timestamp will return a result different than the
-	// timestamp we've persisted in the state. In this case, we can no longer
-	// trust that the value matches what is in the state file.
-	//
-	// To solve this, we must unset the values and allow Terraform to decide whether or
-	// not this resource should be modified or left as-is (ignore_changes).
-	if updatedAt, ok := d.GetOk("updated_at"); ok && updatedAt != secret.UpdatedAt.String() {
-		log.Printf("[INFO] The environment secret %s has been externally updated in GitHub", d.Id())
-		d.Set("encrypted_value", "")
-		d.Set("plaintext_value", "")
-	} else if !ok {
-		if err = d.Set("updated_at", secret.UpdatedAt.String()); err != nil {
-			return err
-		}
-	}
-
-	return nil
+	return checkSecretDrift(d, secret.UpdatedAt.String())
 }
 
 func resourceGithubActionsEnvironmentSecretDelete(d *schema.ResourceData, meta interface{}) error {
@@ -0,0 +1,382 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// hostedRunnerPollInterval is the interval at which a hosted runner is polled
+// while it is provisioned or deleted. It is a variable for the tests.
+var hostedRunnerPollInterval = 10 * time.Second
+
+// hostedRunner is a GitHub-hosted runner of an organization, which go-github
+// does not model yet.
+type hostedRunner struct {
+	ID                 int64                    `json:"id"`
+	Name               string                   `json:"name"`
+	RunnerGroupID      int64                    `json:"runner_group_id"`
+	Platform           string                   `json:"platform"`
+	Status             string                   `json:"status"`
+	MaximumRunners     int                      `json:"maximum_runners"`
+	PublicIPEnabled    bool                     `json:"public_ip_enabled"`
+	PublicIPs          []*hostedRunnerPublicIP  `json:"public_ips"`
+	ImageDetails       *hostedRunnerImage       `json:"image_details"`
+	MachineSizeDetails *hostedRunnerMachineSize `json:"machine_size_details"`
+}
+
+type hostedRunnerPublicIP struct {
+	Enabled bool   `json:"enabled"`
+	Prefix  string `json:"prefix"`
+	Length  int    `json:"length"`
+}
+
+type hostedRunnerImage struct {
+	ID          string `json:"id"`
+	Platform    string `json:"platform,omitempty"`
+	SizeGB      int    `json:"size_gb,omitempty"`
+	DisplayName string `json:"display_name,omitempty"`
+	Source      string `json:"source,omitempty"`
+	Version     string `json:"version,omitempty"`
+}
+
+type hostedRunnerMachineSize struct {
+	ID        string `json:"id"`
+	CPUCores  int    `json:"cpu_cores"`
+	MemoryGB  int    `json:"memory_gb"`
+	StorageGB int    `json:"storage_gb"`
+}
+
+// hostedRunnerRequest is the body creating or updating a hosted runner. The
+// image and size of a runner cannot be updated.
+type hostedRunnerRequest struct {
+	Name           string             `json:"name"`
+	Image          *hostedRunnerImage `json:"image,omitempty"`
+	Size           string             `json:"size,omitempty"`
+	RunnerGroupID  int64              `json:"runner_group_id"`
+	MaximumRunners int                `json:"maximum_runners,omitempty"`
+	EnableStaticIP bool               `json:"enable_static_ip"`
+}
+
+func resourceGithubActionsHostedRunner() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGithubActionsHostedRunnerCreate,
+		Read:   resourceGithubActionsHostedRunnerRead,
+		Update: resourceGithubActionsHostedRunnerUpdate,
+		Delete: resourceGithubActionsHostedRunnerDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(15 * time.Minute),
+			Delete: schema.DefaultTimeout(15 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the runner. It is the label workflows use in 'runs-on'.",
+			},
+			"image_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the image of the runner, as listed by the 'github_actions_hosted_runner_images' data source.",
+			},
+			"image_source": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "github",
+				ForceNew:         true,
+				ValidateDiagFunc: validateValueFunc([]string{"github", "partner", "custom"}),
+				Description:      "The source of the image. Must be one of 'github', 'partner' or 'custom'.",
+			},
+			"size": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The machine size of the runner, as listed by the 'github_actions_hosted_runner_machine_sizes' data source.",
+			},
+			"runner_group_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: "The ID of the runner group the runner belongs to.",
+			},
+			"maximum_runners": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "The maximum number of runners to scale up to.",
+			},
+			"public_ip_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the runner is assigned static public IP ranges.",
+			},
+			"platform": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The operating system and architecture of the runner.",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the runner.",
+			},
+			"image_version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The version of the image of the runner.",
+			},
+			"public_ips": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The static public IP ranges of the runner.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"prefix": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"length": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceGithubActionsHostedRunnerCreate(d *schema.ResourceData, meta interface{}) error {
+	err := checkOrganization(meta)
+	if err != nil {
+		return err
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+	ctx := context.Background()
+
+	body := expandHostedRunnerRequest(d)
+	body.Image = &hostedRunnerImage{
+		ID:     d.Get("image_id").(string),
+		Source: d.Get("image_source").(string),
+	}
+	body.Size = d.Get("size").(string)
+
+	req, err := client.NewRequest("POST", fmt.Sprintf("orgs/%v/actions/hosted-runners", orgName), body)
+	if err != nil {
+		return err
+	}
+
+	runner := new(hostedRunner)
+	if _, err = client.Do(ctx, req, runner); err != nil {
+		return err
+	}
+	d.SetId(strconv.FormatInt(runner.ID, 10))
+
+	if err = waitForHostedRunnerReady(ctx, client, orgName, runner.ID, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return err
+	}
+
+	return resourceGithubActionsHostedRunnerRead(d, meta)
+}
+
+func resourceGithubActionsHostedRunnerRead(d *schema.ResourceData, meta interface{}) error {
+	err := checkOrganization(meta)
+	if err != nil {
+		return err
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return unconvertibleIdErr(d.Id(), err)
+	}
+
+	runner, err := getHostedRunner(ctx, client, orgName, id)
+	if err != nil {
+		if ghErr, ok := err.(*github.ErrorResponse); ok {
+			if ghErr.Response.StatusCode == http.StatusNotFound {
+				log.Printf("[INFO] Removing hosted runner %s/%s from state because it no longer exists in GitHub",
+					orgName, d.Id())
+				d.SetId("")
+				return nil
+			}
+		}
+		return err
+	}
+
+	publicIPs := make([]interface{}, 0, len(runner.PublicIPs))
+	for _, ip := range runner.PublicIPs {
+		publicIPs = append(publicIPs, map[string]interface{}{
+			"enabled": ip.Enabled,
+			"prefix":  ip.Prefix,
+			"length":  ip.Length,
+		})
+	}
+
+	values := map[string]interface{}{
+		"name":              runner.Name,
+		"runner_group_id":   runner.RunnerGroupID,
+		"maximum_runners":   runner.MaximumRunners,
+		"public_ip_enabled": runner.PublicIPEnabled,
+		"platform":          runner.Platform,
+		"status":            runner.Status,
+		"public_ips":        publicIPs,
+	}
+	if runner.ImageDetails != nil {
+		values["image_id"] = runner.ImageDetails.ID
+		values["image_version"] = runner.ImageDetails.Version
+		if runner.ImageDetails.Source != "" {
+			values["image_source"] = runner.ImageDetails.Source
+		}
+	}
+	if runner.MachineSizeDetails != nil {
+		values["size"] = runner.MachineSizeDetails.ID
+	}
+	for k, v := range values {
+		if err = d.Set(k, v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func resourceGithubActionsHostedRunnerUpdate(d *schema.ResourceData, meta interface{}) error {
+	err := checkOrganization(meta)
+	if err != nil {
+		return err
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+
+	req, err := client.NewRequest("PATCH", fmt.Sprintf("orgs/%v/actions/hosted-runners/%v", orgName, d.Id()), expandHostedRunnerRequest(d))
+	if err != nil {
+		return err
+	}
+	if _, err = client.Do(ctx, req, nil); err != nil {
+		return err
+	}
+
+	return resourceGithubActionsHostedRunnerRead(d, meta)
+}
+
+func resourceGithubActionsHostedRunnerDelete(d *schema.ResourceData, meta interface{}) error {
+	err := checkOrganization(meta)
+	if err != nil {
+		return err
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return unconvertibleIdErr(d.Id(), err)
+	}
+
+	req, err := client.NewRequest("DELETE", fmt.Sprintf("orgs/%v/actions/hosted-runners/%v", orgName, id), nil)
+	if err != nil {
+		return err
+	}
+	if _, err = client.Do(ctx, req, nil); err != nil {
+		// The deletion is accepted with a 202 and completes asynchronously.
+		if _, ok := err.(*github.AcceptedError); !ok {
+			return err
+		}
+	}
+
+	// Wait for the runner to be gone so that its runner group can be deleted.
+	return waitForHostedRunnerDeleted(ctx, client, orgName, id, d.Timeout(schema.TimeoutDelete))
+}
+
+func expandHostedRunnerRequest(d *schema.ResourceData) *hostedRunnerRequest {
+	return &hostedRunnerRequest{
+		Name:           d.Get("name").(string),
+		RunnerGroupID:  int64(d.Get("runner_group_id").(int)),
+		MaximumRunners: d.Get("maximum_runners").(int),
+		EnableStaticIP: d.Get("public_ip_enabled").(bool),
+	}
+}
+
+func getHostedRunner(ctx context.Context, client *github.Client, org string, id int64) (*hostedRunner, error) {
+	req, err := client.NewRequest("GET", fmt.Sprintf("orgs/%v/actions/hosted-runners/%v", org, id), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	runner := new(hostedRunner)
+	if _, err = client.Do(ctx, req, runner); err != nil {
+		return nil, err
+	}
+	return runner, nil
+}
+
+func waitForHostedRunnerReady(ctx context.Context, client *github.Client, org string, id int64, timeout time.Duration) error {
+	conf := &retry.StateChangeConf{
+		Pending:      []string{"Provisioning"},
+		Target:       []string{"Ready"},
+		Timeout:      timeout,
+		PollInterval: hostedRunnerPollInterval,
+		Refresh: func() (interface{}, string, error) {
+			runner, err := getHostedRunner(ctx, client, org, id)
+			if err != nil {
+				return nil, "", err
+			}
+			return runner, runner.Status, nil
+		},
+	}
+
+	if _, err := conf.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("error waiting for hosted runner %d to be ready: %s", id, err)
+	}
+	return nil
+}
+
+func waitForHostedRunnerDeleted(ctx context.Context, client *github.Client, org string, id int64, timeout time.Duration) error {
+	conf := &retry.StateChangeConf{
+		Pending:      []string{"Ready", "Provisioning", "Shutdown", "Deleting", "Stuck"},
+		Target:       []string{"deleted"},
+		Timeout:      timeout,
+		PollInterval: hostedRunnerPollInterval,
+		Refresh: func() (interface{}, string, error) {
+			runner, err := getHostedRunner(ctx, client, org, id)
+			if err != nil {
+				if ghErr, ok := err.(*github.ErrorResponse); ok && ghErr.Response.StatusCode == http.StatusNotFound {
+					return id, "deleted", nil
+				}
+				return nil, "", err
+			}
+			return runner, runner.Status, nil
+		},
+	}
+
+	if _, err := conf.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("error waiting for hosted runner %d to be deleted: %s", id, err)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccGithubActionsHostedRunner(t *testing.T) {
+
+	randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+
+	t.Run("creates a hosted runner in a runner group without error", func(t *testing.T) {
+
+		config := fmt.Sprintf(`
+			data "github_actions_hosted_runner_images" "test" {}
+
+			data "github_actions_hosted_runner_machine_sizes" "test" {}
+
+			data "github_actions_hosted_runner_platforms" "test" {}
+
+			resource "github_actions_runner_group" "test" {
+				name       = "tf-acc-test-%[1]s"
+				visibility = "all"
+			}
+
+			resource "github_actions_hosted_runner" "test" {
+				name            = "tf-acc-test-%[1]s"
+				image_id        = [for i in data.github_actions_hosted_runner_images.test.images : i.id if i.platform == "linux-x64"][0]
+				size            = "4-core"
+				runner_group_id = github_actions_runner_group.test.id
+				maximum_runners = 2
+			}
+		`, randomID)
+
+		checks := map[string]resource.TestCheckFunc{
+			"before": resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttrSet("data.github_actions_hosted_runner_images.test", "images.0.id"),
+				resource.TestCheckResourceAttrSet("data.github_actions_hosted_runner_machine_sizes.test", "machine_sizes.0.id"),
+				resource.TestCheckResourceAttrSet("data.github_actions_hosted_runner_platforms.test", "platforms.0"),
+				resource.TestCheckResourceAttr("github_actions_hosted_runner.test", "status", "Ready"),
+				resource.TestCheckResourceAttr("github_actions_hosted_runner.test", "platform", "linux-x64"),
+				resource.TestCheckResourceAttr("github_actions_hosted_runner.test", "maximum_runners", "2"),
+			),
+			"after": resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("github_actions_hosted_runner.test", "maximum_runners", "4"),
+			),
+		}
+
+		testCase := func(t *testing.T, mode string) {
+			resource.Test(t, resource.TestCase{
+				PreCheck:  func() { skipUnlessMode(t, mode) },
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: config,
+						Check:  checks["before"],
+					},
+					{
+						Config: strings.Replace(config,
+							`maximum_runners = 2`,
+							`maximum_runners = 4`, 1),
+						Check: checks["after"],
+					},
+					{
+						ResourceName:      "github_actions_hosted_runner.test",
+						ImportState:       true,
+						ImportStateVerify: true,
+					},
+				},
+			})
+		}
+
+		t.Run("with an anonymous account", func(t *testing.T) {
+			t.Skip("anonymous account not supported for this operation")
+		})
+
+		t.Run("with an individual account", func(t *testing.T) {
+			t.Skip("individual account not supported for this operation")
+		})
+
+		t.Run("with an organization account", func(t *testing.T) {
+			testCase(t, organization)
+		})
+	})
+}
+
+func TestWaitForHostedRunnerDeleted(t *testing.T) {
+	hostedRunnerPollInterval = time.Millisecond
+	defer func() { hostedRunnerPollInterval = 10 * time.Second }()
+
+	ts := githubApiMock([]*mockResponse{
+		{
+			ExpectedUri:    "/orgs/octo/actions/hosted-runners/5",
+			ExpectedMethod: "GET",
+			ResponseBody:   `{"id": 5, "name": "hello", "status": "Deleting"}`,
+			StatusCode:     200,
+		},
+		{
+			ExpectedUri:    "/orgs/octo/actions/hosted-runners/5",
+			ExpectedMethod: "GET",
+			ResponseBody:   `{"message": "Not Found"}`,
+			StatusCode:     404,
+		},
+	})
+	defer ts.Close()
+
+	client := github.NewClient(nil)
+	client.BaseURL, _ = url.Parse(ts.URL + "/")
+
+	if err := waitForHostedRunnerDeleted(context.Background(), client, "octo", 5, time.Minute); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+}
@@ -0,0 +1,116 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceGithubActionsOrganizationOIDCCustomPropertyClaim() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGithubActionsOrganizationOIDCCustomPropertyClaimCreate,
+		Read:   resourceGithubActionsOrganizationOIDCCustomPropertyClaimRead,
+		Delete: resourceGithubActionsOrganizationOIDCCustomPropertyClaimDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"property_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the custom property of repositories to include in the OIDC tokens.",
+			},
+			"claim_key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The key of the claim of the custom property, to use in 'include_claim_keys'.",
+			},
+		},
+	}
+}
+
+func resourceGithubActionsOrganizationOIDCCustomPropertyClaimCreate(d *schema.ResourceData, meta interface{}) error {
+	err := checkOrganization(meta)
+	if err != nil {
+		return err
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+	ctx := context.Background()
+
+	propertyName := d.Get("property_name").(string)
+	req, err := client.NewRequest("POST", fmt.Sprintf("orgs/%v/actions/oidc/customization/properties/repo", orgName),
+		&oidcCustomPropertyInclusion{CustomPropertyName: propertyName})
+	if err != nil {
+		return err
+	}
+	if _, err = client.Do(ctx, req, nil); err != nil {
+		return err
+	}
+	d.SetId(propertyName)
+
+	return resourceGithubActionsOrganizationOIDCCustomPropertyClaimRead(d, meta)
+}
+
+func resourceGithubActionsOrganizationOIDCCustomPropertyClaimRead(d *schema.ResourceData, meta interface{}) error {
+	err := checkOrganization(meta)
+	if err != nil {
+		return err
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+
+	inclusions, err := listOIDCCustomPropertyInclusions(ctx, client, orgName)
+	if err != nil {
+		return err
+	}
+
+	for _, inclusion := range inclusions {
+		if inclusion.CustomPropertyName != d.Id() {
+			continue
+		}
+
+		if err = d.Set("property_name", inclusion.CustomPropertyName); err != nil {
+			return err
+		}
+		if err = d.Set("claim_key", oidcCustomPropertyClaimPrefix+inclusion.CustomPropertyName); err != nil {
+			return err
+		}
+		return nil
+	}
+
+	log.Printf("[INFO] Removing OIDC custom property claim %s/%s from state because it no longer exists in GitHub",
+		orgName, d.Id())
+	d.SetId("")
+	return nil
+}
+
+func resourceGithubActionsOrganizationOIDCCustomPropertyClaimDelete(d *schema.ResourceData, meta interface{}) error {
+	err := checkOrganization(meta)
+	if err != nil {
+		return err
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+
+	req, err := client.NewRequest("DELETE", fmt.Sprintf("orgs/%v/actions/oidc/customization/properties/repo/%v", orgName, url.PathEscape(d.Id())), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(ctx, req, nil)
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	return err
+}
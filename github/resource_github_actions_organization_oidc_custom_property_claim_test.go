@@ -0,0 +1,72 @@
+package github
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccGithubActionsOrganizationOIDCCustomPropertyClaim(t *testing.T) {
+
+	t.Run("includes a custom property in the subject claim", func(t *testing.T) {
+
+		randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+
+		config := fmt.Sprintf(`
+			resource "github_organization_custom_properties" "test" {
+				property_name = "tf-acc-test-%s"
+				value_type    = "string"
+			}
+
+			resource "github_actions_organization_oidc_custom_property_claim" "test" {
+				property_name = github_organization_custom_properties.test.property_name
+			}
+
+			resource "github_actions_organization_oidc_subject_claim_customization_template" "test" {
+				include_claim_keys = ["repo", github_actions_organization_oidc_custom_property_claim.test.claim_key]
+			}
+		`, randomID)
+
+		check := resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttr(
+				"github_actions_organization_oidc_custom_property_claim.test", "claim_key",
+				fmt.Sprintf("repo_property_tf-acc-test-%s", randomID),
+			),
+			resource.TestCheckResourceAttr(
+				"github_actions_organization_oidc_subject_claim_customization_template.test", "include_claim_keys.#", "2",
+			),
+		)
+
+		testCase := func(t *testing.T, mode string) {
+			resource.Test(t, resource.TestCase{
+				PreCheck:  func() { skipUnlessMode(t, mode) },
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: config,
+						Check:  check,
+					},
+					{
+						ResourceName:      "github_actions_organization_oidc_custom_property_claim.test",
+						ImportState:       true,
+						ImportStateVerify: true,
+					},
+				},
+			})
+		}
+
+		t.Run("with an anonymous account", func(t *testing.T) {
+			t.Skip("anonymous account not supported for this operation")
+		})
+
+		t.Run("with an individual account", func(t *testing.T) {
+			t.Skip("individual account not supported for this operation")
+		})
+
+		t.Run("with an organization account", func(t *testing.T) {
+			testCase(t, organization)
+		})
+	})
+}
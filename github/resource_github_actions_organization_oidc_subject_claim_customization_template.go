@@ -16,12 +16,13 @@ func resourceGithubActionsOrganizationOIDCSubjectClaimCustomizationTemplate() *s
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: customizeOIDCClaimKeysDiff,
 		Schema: map[string]*schema.Schema{
 			"include_claim_keys": {
 				Type:        schema.TypeList,
 				Required:    true,
 				MinItems:    1,
-				Description: "A list of OpenID Connect claims.",
+				Description: "A list of OpenID Connect claims. The claims of custom properties are prefixed with 'repo_property_'.",
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
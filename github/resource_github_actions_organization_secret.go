@@ -2,7 +2,6 @@ package github
 
 import (
 	"context"
-	"encoding/base64"
 	"fmt"
 	"log"
 	"net/http"
@@ -30,7 +29,7 @@ func resourceGithubActionsOrganizationSecret() *schema.Resource {
 		// Schema migration added in v6.7.1 to handle the addition of destroy_on_drift field
 		// Resources created before v6.7.0 need the field populated with default value
 		SchemaVersion: 1,
-		MigrateState:  resourceGithubActionsOrganizationSecretMigrateState,
+		MigrateState:  resourceGithubSecretMigrateState,
 
 		Schema: map[string]*schema.Schema{
 			"secret_name": {
@@ -45,7 +44,7 @@ func resourceGithubActionsOrganizationSecret() *schema.Resource {
 				ForceNew:         true,
 				Optional:         true,
 				Sensitive:        true,
-				ConflictsWith:    []string{"plaintext_value"},
+				ConflictsWith:    []string{"plaintext_value", "encrypted_value_wo", "plaintext_value_wo"},
 				Description:      "Encrypted value of the secret using the GitHub public key in Base64 format.",
 				ValidateDiagFunc: toDiagFunc(validation.StringIsBase64, "encrypted_value"),
 			},
@@ -54,9 +53,31 @@ func resourceGithubActionsOrganizationSecret() *schema.Resource {
 				ForceNew:      true,
 				Optional:      true,
 				Sensitive:     true,
-				ConflictsWith: []string{"encrypted_value"},
+				ConflictsWith: []string{"encrypted_value", "encrypted_value_wo", "plaintext_value_wo"},
 				Description:   "Plaintext value of the secret to be encrypted.",
 			},
+			"encrypted_value_wo": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Sensitive:        true,
+				WriteOnly:        true,
+				ConflictsWith:    []string{"encrypted_value", "plaintext_value", "plaintext_value_wo"},
+				Description:      "Write-only encrypted value of the secret using the GitHub public key in Base64 format. It is never stored in the state.",
+				ValidateDiagFunc: toDiagFunc(validation.StringIsBase64, "encrypted_value_wo"),
+			},
+			"plaintext_value_wo": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				WriteOnly:     true,
+				ConflictsWith: []string{"encrypted_value", "plaintext_value", "encrypted_value_wo"},
+				Description:   "Write-only plaintext value of the secret to be encrypted. It is never stored in the state.",
+			},
+			"value_wo_version": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Version of the write-only value. Write-only values are not part of the plan, so change this to upload a new value.",
+			},
 			"visibility": {
 				Type:             schema.TypeString,
 				Required:         true,
@@ -84,9 +105,10 @@ func resourceGithubActionsOrganizationSecret() *schema.Resource {
 				Description: "Date of 'actions_secret' update.",
 			},
 			"destroy_on_drift": {
-				Type:     schema.TypeBool,
-				Default:  true,
-				Optional: true,
+				Type:        schema.TypeBool,
+				Default:     true,
+				Optional:    true,
+				Description: "Boolean indicating whether to recreate the secret if it's modified outside of Terraform. When `true` (default), Terraform will delete and recreate the secret if it detects external changes. When `false`, Terraform will acknowledge external changes but not recreate the secret.",
 			},
 		},
 	}
@@ -98,8 +120,6 @@ func resourceGithubActionsOrganizationSecretCreateOrUpdate(d *schema.ResourceDat
 	ctx := context.Background()
 
 	secretName := d.Get("secret_name").(string)
-	plaintextValue := d.Get("plaintext_value").(string)
-	var encryptedValue string
 
 	visibility := d.Get("visibility").(string)
 	selectedRepositories, hasSelectedRepositories := d.GetOk("selected_repository_ids")
@@ -123,14 +143,9 @@ func resourceGithubActionsOrganizationSecretCreateOrUpdate(d *schema.ResourceDat
 		return err
 	}
 
-	if encryptedText, ok := d.GetOk("encrypted_value"); ok {
-		encryptedValue = encryptedText.(string)
-	} else {
-		encryptedBytes, err := encryptPlaintext(plaintextValue, publicKey)
-		if err != nil {
-			return err
-		}
-		encryptedValue = base64.StdEncoding.EncodeToString(encryptedBytes)
+	encryptedValue, err := getSecretEncryptedValue(d, publicKey)
+	if err != nil {
+		return err
 	}
 
 	// Create an EncryptedSecret and encrypt the plaintext value into it
@@ -209,33 +224,7 @@ func resourceGithubActionsOrganizationSecretRead(d *schema.ResourceData, meta in
 		return err
 	}
 
-	// This is a drift detection mechanism based on timestamps.
-	//
-	// If we do not currently store the "updated_at" field, it means we've only
-	// just created the resource and the value is most likely what we want it to
-	// be.
-	//
-	// If the resource is changed externally in the meantime then reading back
-	// the last update timestamp will return a result different than the
-	// timestamp we've persisted in the state. In that case, we can no longer
-	// trust that the value (which we don't see) is equal to what we've declared
-	// previously.
-	//
-	// The only solution to enforce consistency between is to mark the resource
-	// as deleted (unset the ID) in order to fix potential drift by recreating
-	// the resource.
-	destroyOnDrift := d.Get("destroy_on_drift").(bool)
-	if updatedAt, ok := d.GetOk("updated_at"); ok && destroyOnDrift && updatedAt != secret.UpdatedAt.String() {
-		log.Printf("[INFO] The secret %s has been externally updated in GitHub", d.Id())
-		d.SetId("")
-	}
-
-	// Always update the timestamp to prevent repeated drift detection
-	if err = d.Set("updated_at", secret.UpdatedAt.String()); err != nil {
-		return err
-	}
-
-	return nil
+	return checkSecretDrift(d, secret.UpdatedAt.String())
 }
 
 func resourceGithubActionsOrganizationSecretDelete(d *schema.ResourceData, meta interface{}) error {
@@ -0,0 +1,82 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceGithubActionsOrganizationSettings() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGithubActionsOrganizationSettingsCreateOrUpdate,
+		Read:   resourceGithubActionsOrganizationSettingsRead,
+		Update: resourceGithubActionsOrganizationSettingsCreateOrUpdate,
+		Delete: resourceGithubActionsOrganizationSettingsDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: actionsSettingsSchema(),
+	}
+}
+
+func resourceGithubActionsOrganizationSettingsCreateOrUpdate(d *schema.ResourceData, meta interface{}) error {
+	err := checkOrganization(meta)
+	if err != nil {
+		return err
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+	ctx := context.Background()
+	if !d.IsNewResource() {
+		ctx = context.WithValue(ctx, ctxId, d.Id())
+	}
+
+	if err = updateActionsSettings(ctx, d, client, fmt.Sprintf("orgs/%v", orgName)); err != nil {
+		return err
+	}
+
+	d.SetId(orgName)
+	return resourceGithubActionsOrganizationSettingsRead(d, meta)
+}
+
+func resourceGithubActionsOrganizationSettingsRead(d *schema.ResourceData, meta interface{}) error {
+	err := checkOrganization(meta)
+	if err != nil {
+		return err
+	}
+
+	client := meta.(*Owner).v3client
+	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+
+	if _, _, err = client.Organizations.Get(ctx, d.Id()); err != nil {
+		if ghErr, ok := err.(*github.ErrorResponse); ok {
+			if ghErr.Response.StatusCode == http.StatusNotFound {
+				log.Printf("[INFO] Removing Actions settings of organization %s from state because it no longer exists in GitHub",
+					d.Id())
+				d.SetId("")
+				return nil
+			}
+		}
+		return err
+	}
+
+	return readActionsSettings(ctx, d, client, fmt.Sprintf("orgs/%v", d.Id()))
+}
+
+func resourceGithubActionsOrganizationSettingsDelete(d *schema.ResourceData, meta interface{}) error {
+	err := checkOrganization(meta)
+	if err != nil {
+		return err
+	}
+
+	client := meta.(*Owner).v3client
+	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+
+	return resetActionsSettings(ctx, d, client, fmt.Sprintf("orgs/%v", d.Id()))
+}
@@ -18,6 +18,7 @@ func resourceGithubActionsRepositoryOIDCSubjectClaimCustomizationTemplate() *sch
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: resourceGithubActionsRepositoryOIDCSubjectClaimCustomizationTemplateDiff,
 		Schema: map[string]*schema.Schema{
 			"repository": {
 				Type:             schema.TypeString,
@@ -27,14 +28,15 @@ func resourceGithubActionsRepositoryOIDCSubjectClaimCustomizationTemplate() *sch
 			},
 			"use_default": {
 				Type:        schema.TypeBool,
-				Required:    true,
+				Optional:    true,
+				Default:     false,
 				Description: "Whether to use the default template or not. If 'true', 'include_claim_keys' must not be set.",
 			},
 			"include_claim_keys": {
 				Type:        schema.TypeList,
 				Optional:    true,
 				MinItems:    1,
-				Description: "A list of OpenID Connect claims.",
+				Description: "A list of OpenID Connect claims. The claims of custom properties are prefixed with 'repo_property_'.",
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
@@ -43,6 +45,21 @@ func resourceGithubActionsRepositoryOIDCSubjectClaimCustomizationTemplate() *sch
 	}
 }
 
+func resourceGithubActionsRepositoryOIDCSubjectClaimCustomizationTemplateDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("use_default") || !d.NewValueKnown("include_claim_keys") {
+		return nil
+	}
+
+	if d.Get("use_default").(bool) {
+		if len(d.Get("include_claim_keys").([]interface{})) > 0 {
+			return errors.New("include_claim_keys cannot be set when use_default is true")
+		}
+		return nil
+	}
+
+	return customizeOIDCClaimKeysDiff(ctx, d, meta)
+}
+
 func resourceGithubActionsRepositoryOIDCSubjectClaimCustomizationTemplateCreateOrUpdate(d *schema.ResourceData, meta interface{}) error {
 
 	client := meta.(*Owner).v3client
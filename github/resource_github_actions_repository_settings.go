@@ -0,0 +1,84 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceGithubActionsRepositorySettings() *schema.Resource {
+	s := actionsSettingsSchema()
+	s["repository"] = &schema.Schema{
+		Type:             schema.TypeString,
+		Required:         true,
+		ForceNew:         true,
+		Description:      "The GitHub repository.",
+		ValidateDiagFunc: toDiagFunc(validation.StringLenBetween(1, 100), "repository"),
+	}
+
+	return &schema.Resource{
+		Create: resourceGithubActionsRepositorySettingsCreateOrUpdate,
+		Read:   resourceGithubActionsRepositorySettingsRead,
+		Update: resourceGithubActionsRepositorySettingsCreateOrUpdate,
+		Delete: resourceGithubActionsRepositorySettingsDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: s,
+	}
+}
+
+func resourceGithubActionsRepositorySettingsCreateOrUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Owner).v3client
+	owner := meta.(*Owner).name
+	repoName := d.Get("repository").(string)
+	ctx := context.Background()
+	if !d.IsNewResource() {
+		ctx = context.WithValue(ctx, ctxId, d.Id())
+	}
+
+	if err := updateActionsSettings(ctx, d, client, fmt.Sprintf("repos/%v/%v", owner, repoName)); err != nil {
+		return err
+	}
+
+	d.SetId(repoName)
+	return resourceGithubActionsRepositorySettingsRead(d, meta)
+}
+
+func resourceGithubActionsRepositorySettingsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Owner).v3client
+	owner := meta.(*Owner).name
+	repoName := d.Id()
+	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+
+	if _, _, err := client.Repositories.Get(ctx, owner, repoName); err != nil {
+		if ghErr, ok := err.(*github.ErrorResponse); ok {
+			if ghErr.Response.StatusCode == http.StatusNotFound {
+				log.Printf("[INFO] Removing Actions settings of repository %s/%s from state because it no longer exists in GitHub",
+					owner, repoName)
+				d.SetId("")
+				return nil
+			}
+		}
+		return err
+	}
+
+	if err := readActionsSettings(ctx, d, client, fmt.Sprintf("repos/%v/%v", owner, repoName)); err != nil {
+		return err
+	}
+	return d.Set("repository", repoName)
+}
+
+func resourceGithubActionsRepositorySettingsDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Owner).v3client
+	owner := meta.(*Owner).name
+	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+
+	return resetActionsSettings(ctx, d, client, fmt.Sprintf("repos/%v/%v", owner, d.Id()))
+}
@@ -0,0 +1,194 @@
+package github
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccGithubActionsRepositorySettings(t *testing.T) {
+
+	t.Run("manages the retention and fork pull request settings of a repository", func(t *testing.T) {
+
+		randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+
+		config := fmt.Sprintf(`
+			resource "github_repository" "test" {
+				name       = "tf-acc-test-%s"
+				visibility = "private"
+			}
+
+			resource "github_actions_repository_settings" "test" {
+				repository                      = github_repository.test.name
+				artifact_and_log_retention_days = 30
+
+				private_fork_pr_workflows {
+					run_workflows_from_fork_pull_requests  = true
+					require_approval_for_fork_pr_workflows = true
+				}
+			}
+		`, randomID)
+
+		checks := map[string]resource.TestCheckFunc{
+			"before": resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr(
+					"github_actions_repository_settings.test", "artifact_and_log_retention_days", "30",
+				),
+				resource.TestCheckResourceAttr(
+					"github_actions_repository_settings.test", "private_fork_pr_workflows.0.send_secrets_and_variables", "false",
+				),
+			),
+			"after": resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr(
+					"github_actions_repository_settings.test", "artifact_and_log_retention_days", "10",
+				),
+			),
+		}
+
+		testCase := func(t *testing.T, mode string) {
+			resource.Test(t, resource.TestCase{
+				PreCheck:  func() { skipUnlessMode(t, mode) },
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: config,
+						Check:  checks["before"],
+					},
+					{
+						Config: strings.Replace(config,
+							"artifact_and_log_retention_days = 30",
+							"artifact_and_log_retention_days = 10", 1),
+						Check: checks["after"],
+					},
+				},
+			})
+		}
+
+		t.Run("with an anonymous account", func(t *testing.T) {
+			t.Skip("anonymous account not supported for this operation")
+		})
+
+		t.Run("with an individual account", func(t *testing.T) {
+			testCase(t, individual)
+		})
+
+		t.Run("with an organization account", func(t *testing.T) {
+			testCase(t, organization)
+		})
+	})
+}
+
+func TestResourceGithubActionsRepositorySettingsCreate(t *testing.T) {
+	ts := githubApiMock([]*mockResponse{
+		{
+			ExpectedUri:    "/repos/octo/hello/actions/permissions/artifact-and-log-retention",
+			ExpectedMethod: "PUT",
+			ExpectedBody:   []byte(`{"days":30}` + "\n"),
+			StatusCode:     204,
+		},
+		{
+			ExpectedUri:    "/repos/octo/hello/actions/permissions/fork-pr-contributor-approval",
+			ExpectedMethod: "PUT",
+			ExpectedBody:   []byte(`{"approval_policy":"all_external_contributors"}` + "\n"),
+			StatusCode:     204,
+		},
+		{
+			ExpectedUri:    "/repos/octo/hello",
+			ExpectedMethod: "GET",
+			ResponseBody:   `{"name": "hello"}`,
+			StatusCode:     200,
+		},
+		{
+			ExpectedUri:    "/repos/octo/hello/actions/permissions/artifact-and-log-retention",
+			ExpectedMethod: "GET",
+			ResponseBody:   `{"days": 30, "maximum_allowed_days": 90}`,
+			StatusCode:     200,
+		},
+		{
+			ExpectedUri:    "/repos/octo/hello/actions/cache/retention-limit",
+			ExpectedMethod: "GET",
+			ResponseBody:   `{"message": "Not Found"}`,
+			StatusCode:     404,
+		},
+		{
+			ExpectedUri:    "/repos/octo/hello/actions/cache/storage-limit",
+			ExpectedMethod: "GET",
+			ResponseBody:   `{"max_cache_size_gb": 10}`,
+			StatusCode:     200,
+		},
+		{
+			ExpectedUri:    "/repos/octo/hello/actions/permissions/fork-pr-contributor-approval",
+			ExpectedMethod: "GET",
+			ResponseBody:   `{"approval_policy": "all_external_contributors"}`,
+			StatusCode:     200,
+		},
+		{
+			ExpectedUri:    "/repos/octo/hello/actions/permissions/fork-pr-workflows-private-repos",
+			ExpectedMethod: "GET",
+			ResponseBody:   `{"run_workflows_from_fork_pull_requests": false}`,
+			StatusCode:     200,
+		},
+	})
+	defer ts.Close()
+
+	client := github.NewClient(nil)
+	client.BaseURL, _ = url.Parse(ts.URL + "/")
+	meta := &Owner{name: "octo", v3client: client}
+
+	d := schema.TestResourceDataRaw(t, resourceGithubActionsRepositorySettings().Schema, map[string]interface{}{
+		"repository":                      "hello",
+		"artifact_and_log_retention_days": 30,
+		"fork_pr_contributor_approval":    "all_external_contributors",
+	})
+
+	if err := resourceGithubActionsRepositorySettingsCreateOrUpdate(d, meta); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if d.Id() != "hello" {
+		t.Errorf("Expected the ID to be hello, got %s", d.Id())
+	}
+	if days := d.Get("artifact_and_log_retention_days").(int); days != 30 {
+		t.Errorf("Expected the retention to be 30 days, got %d", days)
+	}
+	if size := d.Get("cache_storage_limit_gb").(int); size != 10 {
+		t.Errorf("Expected the unconfigured cache storage limit to be read, got %d", size)
+	}
+	if workflows := d.Get("private_fork_pr_workflows").([]interface{}); len(workflows) != 0 {
+		t.Errorf("Expected the fork pull request workflows to be left out while off, got %v", workflows)
+	}
+}
+
+func TestResourceGithubActionsRepositorySettingsRead_notFound(t *testing.T) {
+	ts := githubApiMock([]*mockResponse{
+		{
+			ExpectedUri:    "/repos/octo/hello",
+			ExpectedMethod: "GET",
+			ResponseBody:   `{"message": "Not Found"}`,
+			StatusCode:     404,
+		},
+	})
+	defer ts.Close()
+
+	client := github.NewClient(nil)
+	client.BaseURL, _ = url.Parse(ts.URL + "/")
+	meta := &Owner{name: "octo", v3client: client}
+
+	d := schema.TestResourceDataRaw(t, resourceGithubActionsRepositorySettings().Schema, map[string]interface{}{
+		"repository": "hello",
+	})
+	d.SetId("hello")
+
+	if err := resourceGithubActionsRepositorySettingsRead(d, meta); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if d.Id() != "" {
+		t.Errorf("Expected the settings to be removed from state, got ID %q", d.Id())
+	}
+}
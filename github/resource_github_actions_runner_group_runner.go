@@ -0,0 +1,148 @@
+package github
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceGithubActionsRunnerGroupRunner() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGithubActionsRunnerGroupRunnerCreate,
+		Read:   resourceGithubActionsRunnerGroupRunnerRead,
+		Delete: resourceGithubActionsRunnerGroupRunnerDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceGithubActionsRunnerGroupRunnerImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"runner_group_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the runner group.",
+			},
+			"runner_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the self-hosted runner to move into the runner group.",
+			},
+		},
+	}
+}
+
+func resourceGithubActionsRunnerGroupRunnerCreate(d *schema.ResourceData, meta interface{}) error {
+	err := checkOrganization(meta)
+	if err != nil {
+		return err
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+	ctx := context.Background()
+
+	groupID := int64(d.Get("runner_group_id").(int))
+	runnerID := int64(d.Get("runner_id").(int))
+
+	// A runner belongs to a single group, adding it to a group moves it.
+	if _, err = client.Actions.AddRunnerGroupRunners(ctx, orgName, groupID, runnerID); err != nil {
+		return err
+	}
+	d.SetId(buildTwoPartID(strconv.FormatInt(groupID, 10), strconv.FormatInt(runnerID, 10)))
+
+	return resourceGithubActionsRunnerGroupRunnerRead(d, meta)
+}
+
+func resourceGithubActionsRunnerGroupRunnerRead(d *schema.ResourceData, meta interface{}) error {
+	err := checkOrganization(meta)
+	if err != nil {
+		return err
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+
+	groupID := int64(d.Get("runner_group_id").(int))
+	runnerID := int64(d.Get("runner_id").(int))
+
+	options := &github.ListOptions{
+		PerPage: maxPerPage,
+	}
+
+	for {
+		runners, resp, err := client.Actions.ListRunnerGroupRunners(ctx, orgName, groupID, options)
+		if err != nil {
+			if ghErr, ok := err.(*github.ErrorResponse); ok {
+				if ghErr.Response.StatusCode == http.StatusNotFound {
+					log.Printf("[INFO] Removing runner group runner %s/%s from state because the runner group no longer exists in GitHub",
+						orgName, d.Id())
+					d.SetId("")
+					return nil
+				}
+			}
+			return err
+		}
+
+		for _, runner := range runners.Runners {
+			if runner.GetID() == runnerID {
+				return nil
+			}
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		options.Page = resp.NextPage
+	}
+
+	log.Printf("[INFO] Removing runner group runner %s/%s from state because the runner is no longer in the runner group",
+		orgName, d.Id())
+	d.SetId("")
+	return nil
+}
+
+func resourceGithubActionsRunnerGroupRunnerDelete(d *schema.ResourceData, meta interface{}) error {
+	err := checkOrganization(meta)
+	if err != nil {
+		return err
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+
+	groupID := int64(d.Get("runner_group_id").(int))
+	runnerID := int64(d.Get("runner_id").(int))
+
+	// The runner is returned to the default runner group.
+	resp, err := client.Actions.RemoveRunnerGroupRunners(ctx, orgName, groupID, runnerID)
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	return err
+}
+
+func resourceGithubActionsRunnerGroupRunnerImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	groupID, runnerID, err := parseTwoPartID(d.Id(), "runner_group_id", "runner_id")
+	if err != nil {
+		return nil, err
+	}
+
+	for key, value := range map[string]string{"runner_group_id": groupID, "runner_id": runnerID} {
+		id, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, unconvertibleIdErr(value, err)
+		}
+		if err = d.Set(key, id); err != nil {
+			return nil, err
+		}
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,189 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceGithubActionsRunnerLabels() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGithubActionsRunnerLabelsCreateOrUpdate,
+		Read:   resourceGithubActionsRunnerLabelsRead,
+		Update: resourceGithubActionsRunnerLabelsCreateOrUpdate,
+		Delete: resourceGithubActionsRunnerLabelsDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceGithubActionsRunnerLabelsImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"repository": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The name of the repository of the runner. Organization runners are managed when not set.",
+			},
+			"runner_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the self-hosted runner.",
+			},
+			"labels": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "The custom labels of the runner.",
+			},
+			"all_labels": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "All the labels of the runner, including the read-only labels assigned by GitHub.",
+			},
+		},
+	}
+}
+
+// runnerLabel is a label of a self-hosted runner. Read-only labels are
+// assigned by GitHub, such as 'self-hosted' or the operating system.
+type runnerLabel struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+func resourceGithubActionsRunnerLabelsCreateOrUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Owner).v3client
+	ctx := context.Background()
+
+	runnerID := int64(d.Get("runner_id").(int))
+	labels := expandStringList(d.Get("labels").(*schema.Set).List())
+
+	body := map[string]interface{}{"labels": labels}
+	req, err := client.NewRequest("PUT", runnerLabelsURL(meta, d.Get("repository").(string), runnerID), body)
+	if err != nil {
+		return err
+	}
+	if _, err = client.Do(ctx, req, nil); err != nil {
+		return err
+	}
+
+	if repoName := d.Get("repository").(string); repoName != "" {
+		d.SetId(buildTwoPartID(repoName, strconv.FormatInt(runnerID, 10)))
+	} else {
+		d.SetId(strconv.FormatInt(runnerID, 10))
+	}
+
+	return resourceGithubActionsRunnerLabelsRead(d, meta)
+}
+
+func resourceGithubActionsRunnerLabelsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Owner).v3client
+	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+
+	runnerID := int64(d.Get("runner_id").(int))
+	labels, err := listRunnerLabels(ctx, client, runnerLabelsURL(meta, d.Get("repository").(string), runnerID))
+	if err != nil {
+		if ghErr, ok := err.(*github.ErrorResponse); ok {
+			if ghErr.Response.StatusCode == http.StatusNotFound {
+				log.Printf("[INFO] Removing labels of runner %s from state because the runner no longer exists in GitHub",
+					d.Id())
+				d.SetId("")
+				return nil
+			}
+		}
+		return err
+	}
+
+	custom := []string{}
+	all := []string{}
+	for _, label := range labels {
+		all = append(all, label.Name)
+		if label.Type == "custom" {
+			custom = append(custom, label.Name)
+		}
+	}
+
+	if err = d.Set("labels", flattenStringList(custom)); err != nil {
+		return err
+	}
+	if err = d.Set("all_labels", flattenStringList(all)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceGithubActionsRunnerLabelsDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Owner).v3client
+	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+
+	runnerID := int64(d.Get("runner_id").(int))
+	req, err := client.NewRequest("DELETE", runnerLabelsURL(meta, d.Get("repository").(string), runnerID), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(ctx, req, nil)
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	return err
+}
+
+func resourceGithubActionsRunnerLabelsImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	id := d.Id()
+	if strings.Contains(id, ":") {
+		repoName, runnerID, err := parseTwoPartID(id, "repository", "runner_id")
+		if err != nil {
+			return nil, err
+		}
+		if err = d.Set("repository", repoName); err != nil {
+			return nil, err
+		}
+		id = runnerID
+	}
+
+	runnerID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, unconvertibleIdErr(id, err)
+	}
+	if err = d.Set("runner_id", runnerID); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// runnerLabelsURL returns the URL of the labels of an organization runner,
+// or of a repository runner when repoName is set.
+func runnerLabelsURL(meta interface{}, repoName string, runnerID int64) string {
+	owner := meta.(*Owner).name
+	if repoName != "" {
+		return fmt.Sprintf("repos/%v/%v/actions/runners/%v/labels", owner, repoName, runnerID)
+	}
+	return fmt.Sprintf("orgs/%v/actions/runners/%v/labels", owner, runnerID)
+}
+
+func listRunnerLabels(ctx context.Context, client *github.Client, u string) ([]*runnerLabel, error) {
+	req, err := client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Labels []*runnerLabel `json:"labels"`
+	}
+	if _, err = client.Do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result.Labels, nil
+}
@@ -0,0 +1,47 @@
+package github
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestResourceGithubActionsRunnerLabelsRead(t *testing.T) {
+	ts := githubApiMock([]*mockResponse{
+		{
+			ExpectedUri:    "/repos/octo/hello/actions/runners/7/labels",
+			ExpectedMethod: "GET",
+			ResponseBody: `{"total_count": 3, "labels": [
+				{"id": 1, "name": "self-hosted", "type": "read-only"},
+				{"id": 2, "name": "linux", "type": "read-only"},
+				{"id": 3, "name": "gpu", "type": "custom"}
+			]}`,
+			StatusCode: 200,
+		},
+	})
+	defer ts.Close()
+
+	client := github.NewClient(nil)
+	client.BaseURL, _ = url.Parse(ts.URL + "/")
+	meta := &Owner{name: "octo", v3client: client}
+
+	d := schema.TestResourceDataRaw(t, resourceGithubActionsRunnerLabels().Schema, map[string]interface{}{
+		"repository": "hello",
+		"runner_id":  7,
+		"labels":     []interface{}{"gpu"},
+	})
+	d.SetId("hello:7")
+
+	if err := resourceGithubActionsRunnerLabelsRead(d, meta); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if labels := d.Get("labels").(*schema.Set); labels.Len() != 1 || !labels.Contains("gpu") {
+		t.Errorf("Expected the custom labels to be [gpu], got %v", labels.List())
+	}
+	if all := d.Get("all_labels").(*schema.Set); all.Len() != 3 {
+		t.Errorf("Expected 3 labels, got %v", all.List())
+	}
+}
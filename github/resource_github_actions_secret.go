@@ -26,7 +26,7 @@ func resourceGithubActionsSecret() *schema.Resource {
 		// Schema migration added to handle the addition of destroy_on_drift field
 		// Resources created before this field was added need it populated with default value
 		SchemaVersion: 1,
-		MigrateState:  resourceGithubActionsSecretMigrateState,
+		MigrateState:  resourceGithubSecretMigrateState,
 
 		Schema: map[string]*schema.Schema{
 			"repository": {
@@ -47,7 +47,7 @@ func resourceGithubActionsSecret() *schema.Resource {
 				ForceNew:      true,
 				Optional:      true,
 				Sensitive:     true,
-				ConflictsWith: []string{"plaintext_value"},
+				ConflictsWith: []string{"plaintext_value", "encrypted_value_wo", "plaintext_value_wo"},
 				Description:   "Encrypted value of the secret using the GitHub public key in Base64 format.",
 			},
 			"plaintext_value": {
@@ -55,9 +55,30 @@ func resourceGithubActionsSecret() *schema.Resource {
 				ForceNew:      true,
 				Optional:      true,
 				Sensitive:     true,
-				ConflictsWith: []string{"encrypted_value"},
+				ConflictsWith: []string{"encrypted_value", "encrypted_value_wo", "plaintext_value_wo"},
 				Description:   "Plaintext value of the secret to be encrypted.",
 			},
+			"encrypted_value_wo": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				WriteOnly:     true,
+				ConflictsWith: []string{"encrypted_value", "plaintext_value", "plaintext_value_wo"},
+				Description:   "Write-only encrypted value of the secret using the GitHub public key in Base64 format. It is never stored in the state.",
+			},
+			"plaintext_value_wo": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				WriteOnly:     true,
+				ConflictsWith: []string{"encrypted_value", "plaintext_value", "encrypted_value_wo"},
+				Description:   "Write-only plaintext value of the secret to be encrypted. It is never stored in the state.",
+			},
+			"value_wo_version": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Version of the write-only value. Write-only values are not part of the plan, so change this to upload a new value.",
+			},
 			"created_at": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -85,22 +106,15 @@ func resourceGithubActionsSecretCreateOrUpdate(d *schema.ResourceData, meta inte
 
 	repo := d.Get("repository").(string)
 	secretName := d.Get("secret_name").(string)
-	plaintextValue := d.Get("plaintext_value").(string)
-	var encryptedValue string
 
 	keyId, publicKey, err := getPublicKeyDetails(owner, repo, meta)
 	if err != nil {
 		return err
 	}
 
-	if encryptedText, ok := d.GetOk("encrypted_value"); ok {
-		encryptedValue = encryptedText.(string)
-	} else {
-		encryptedBytes, err := encryptPlaintext(plaintextValue, publicKey)
-		if err != nil {
-			return err
-		}
-		encryptedValue = base64.StdEncoding.EncodeToString(encryptedBytes)
+	encryptedValue, err := getSecretEncryptedValue(d, publicKey)
+	if err != nil {
+		return err
 	}
 
 	// Create an EncryptedSecret and encrypt the plaintext value into it
@@ -152,33 +166,7 @@ func resourceGithubActionsSecretRead(d *schema.ResourceData, meta interface{}) e
 		return err
 	}
 
-	// This is a drift detection mechanism based on timestamps.
-	//
-	// If we do not currently store the "updated_at" field, it means we've only
-	// just created the resource and the value is most likely what we want it to
-	// be.
-	//
-	// If the resource is changed externally in the meantime then reading back
-	// the last update timestamp will return a result different than the
-	// timestamp we've persisted in the state. In that case, we can no longer
-	// trust that the value (which we don't see) is equal to what we've declared
-	// previously.
-	//
-	// The only solution to enforce consistency between is to mark the resource
-	// as deleted (unset the ID) in order to fix potential drift by recreating
-	// the resource.
-	destroyOnDrift := d.Get("destroy_on_drift").(bool)
-	if updatedAt, ok := d.GetOk("updated_at"); ok && destroyOnDrift && updatedAt != secret.UpdatedAt.String() {
-		log.Printf("[INFO] The secret %s has been externally updated in GitHub", d.Id())
-		d.SetId("")
-	}
-
-	// Always update the timestamp to prevent repeated drift detection
-	if err = d.Set("updated_at", secret.UpdatedAt.String()); err != nil {
-		return err
-	}
-
-	return nil
+	return checkSecretDrift(d, secret.UpdatedAt.String())
 }
 
 func resourceGithubActionsSecretDelete(d *schema.ResourceData, meta interface{}) error {
@@ -0,0 +1,152 @@
+package github
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceGithubActionsWorkflow() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGithubActionsWorkflowCreateOrUpdate,
+		Read:   resourceGithubActionsWorkflowRead,
+		Update: resourceGithubActionsWorkflowCreateOrUpdate,
+		Delete: resourceGithubActionsWorkflowDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"repository": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the repository of the workflow.",
+			},
+			"workflow_file": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The file name of the workflow, such as 'ci.yml'.",
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the workflow is enabled.",
+			},
+			"workflow_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The ID of the workflow.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the workflow.",
+			},
+			"path": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The path of the workflow file in the repository.",
+			},
+			"state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The state of the workflow, such as 'active' or 'disabled_manually'.",
+			},
+		},
+	}
+}
+
+func resourceGithubActionsWorkflowCreateOrUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Owner).v3client
+	owner := meta.(*Owner).name
+	repoName := d.Get("repository").(string)
+	workflowFile := d.Get("workflow_file").(string)
+	ctx := context.Background()
+	if !d.IsNewResource() {
+		ctx = context.WithValue(ctx, ctxId, d.Id())
+	}
+
+	var err error
+	if d.Get("enabled").(bool) {
+		_, err = client.Actions.EnableWorkflowByFileName(ctx, owner, repoName, workflowFile)
+	} else {
+		_, err = client.Actions.DisableWorkflowByFileName(ctx, owner, repoName, workflowFile)
+	}
+	if err != nil {
+		return err
+	}
+
+	d.SetId(buildTwoPartID(repoName, workflowFile))
+	return resourceGithubActionsWorkflowRead(d, meta)
+}
+
+func resourceGithubActionsWorkflowRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Owner).v3client
+	owner := meta.(*Owner).name
+	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+
+	repoName, workflowFile, err := parseTwoPartID(d.Id(), "repository", "workflow_file")
+	if err != nil {
+		return err
+	}
+
+	workflow, _, err := client.Actions.GetWorkflowByFileName(ctx, owner, repoName, workflowFile)
+	if err != nil {
+		if ghErr, ok := err.(*github.ErrorResponse); ok {
+			if ghErr.Response.StatusCode == http.StatusNotFound {
+				log.Printf("[INFO] Removing workflow %s/%s from state because it no longer exists in GitHub",
+					owner, d.Id())
+				d.SetId("")
+				return nil
+			}
+		}
+		return err
+	}
+
+	if err = d.Set("repository", repoName); err != nil {
+		return err
+	}
+	if err = d.Set("workflow_file", workflowFile); err != nil {
+		return err
+	}
+	if err = d.Set("enabled", workflow.GetState() == "active"); err != nil {
+		return err
+	}
+	if err = d.Set("workflow_id", workflow.GetID()); err != nil {
+		return err
+	}
+	if err = d.Set("name", workflow.GetName()); err != nil {
+		return err
+	}
+	if err = d.Set("path", workflow.GetPath()); err != nil {
+		return err
+	}
+	if err = d.Set("state", workflow.GetState()); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceGithubActionsWorkflowDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Owner).v3client
+	owner := meta.(*Owner).name
+	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+
+	// Workflows are enabled unless they are disabled, so the workflow is
+	// enabled again when it is no longer managed.
+	if d.Get("enabled").(bool) {
+		return nil
+	}
+	resp, err := client.Actions.EnableWorkflowByFileName(ctx, owner, d.Get("repository").(string), d.Get("workflow_file").(string))
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	return err
+}
@@ -2,7 +2,6 @@ package github
 
 import (
 	"context"
-	"encoding/base64"
 	"fmt"
 	"log"
 	"net/http"
@@ -16,11 +15,15 @@ func resourceGithubCodespacesSecret() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceGithubCodespacesSecretCreateOrUpdate,
 		Read:   resourceGithubCodespacesSecretRead,
+		Update: resourceGithubCodespacesSecretCreateOrUpdate,
 		Delete: resourceGithubCodespacesSecretDelete,
 		Importer: &schema.ResourceImporter{
 			State: resourceGithubCodespacesSecretImport,
 		},
 
+		SchemaVersion: 1,
+		MigrateState:  resourceGithubSecretMigrateState,
+
 		Schema: map[string]*schema.Schema{
 			"repository": {
 				Type:        schema.TypeString,
@@ -40,7 +43,7 @@ func resourceGithubCodespacesSecret() *schema.Resource {
 				ForceNew:      true,
 				Optional:      true,
 				Sensitive:     true,
-				ConflictsWith: []string{"plaintext_value"},
+				ConflictsWith: []string{"plaintext_value", "encrypted_value_wo", "plaintext_value_wo"},
 				Description:   "Encrypted value of the secret using the GitHub public key in Base64 format.",
 			},
 			"plaintext_value": {
@@ -48,9 +51,30 @@ func resourceGithubCodespacesSecret() *schema.Resource {
 				ForceNew:      true,
 				Optional:      true,
 				Sensitive:     true,
-				ConflictsWith: []string{"encrypted_value"},
+				ConflictsWith: []string{"encrypted_value", "encrypted_value_wo", "plaintext_value_wo"},
 				Description:   "Plaintext value of the secret to be encrypted.",
 			},
+			"encrypted_value_wo": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				WriteOnly:     true,
+				ConflictsWith: []string{"encrypted_value", "plaintext_value", "plaintext_value_wo"},
+				Description:   "Write-only encrypted value of the secret using the GitHub public key in Base64 format. It is never stored in the state.",
+			},
+			"plaintext_value_wo": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				WriteOnly:     true,
+				ConflictsWith: []string{"encrypted_value", "plaintext_value", "encrypted_value_wo"},
+				Description:   "Write-only plaintext value of the secret to be encrypted. It is never stored in the state.",
+			},
+			"value_wo_version": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Version of the write-only value. Write-only values are not part of the plan, so change this to upload a new value.",
+			},
 			"created_at": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -61,6 +85,12 @@ func resourceGithubCodespacesSecret() *schema.Resource {
 				Computed:    true,
 				Description: "Date of 'codespaces_secret' update.",
 			},
+			"destroy_on_drift": {
+				Type:        schema.TypeBool,
+				Default:     true,
+				Optional:    true,
+				Description: "Boolean indicating whether to recreate the secret if it's modified outside of Terraform. When `true` (default), Terraform will delete and recreate the secret if it detects external changes. When `false`, Terraform will acknowledge external changes but not recreate the secret.",
+			},
 		},
 	}
 }
@@ -72,22 +102,15 @@ func resourceGithubCodespacesSecretCreateOrUpdate(d *schema.ResourceData, meta i
 
 	repo := d.Get("repository").(string)
 	secretName := d.Get("secret_name").(string)
-	plaintextValue := d.Get("plaintext_value").(string)
-	var encryptedValue string
 
 	keyId, publicKey, err := getCodespacesPublicKeyDetails(owner, repo, meta)
 	if err != nil {
 		return err
 	}
 
-	if encryptedText, ok := d.GetOk("encrypted_value"); ok {
-		encryptedValue = encryptedText.(string)
-	} else {
-		encryptedBytes, err := encryptPlaintext(plaintextValue, publicKey)
-		if err != nil {
-			return err
-		}
-		encryptedValue = base64.StdEncoding.EncodeToString(encryptedBytes)
+	encryptedValue, err := getSecretEncryptedValue(d, publicKey)
+	if err != nil {
+		return err
 	}
 
 	// Create an EncryptedSecret and encrypt the plaintext value into it
@@ -139,31 +162,7 @@ func resourceGithubCodespacesSecretRead(d *schema.ResourceData, meta interface{}
 		return err
 	}
 
-	// This is a drift detection mechanism based on timestamps.
-	//
-	// If we do not currently store the "updated_at" field, it means we've only
-	// just created the resource and the value is most likely what we want it to
-	// be.
-	//
-	// If the resource is changed externally in the meantime then reading back
-	// the last update timestamp will return a result different than the
-	// timestamp we've persisted in the state. In that case, we can no longer
-	// trust that the value (which we don't see) is equal to what we've declared
-	// previously.
-	//
-	// The only solution to enforce consistency between is to mark the resource
-	// as deleted (unset the ID) in order to fix potential drift by recreating
-	// the resource.
-	if updatedAt, ok := d.GetOk("updated_at"); ok && updatedAt != secret.UpdatedAt.String() {
-		log.Printf("[WARN] The secret %s has been externally updated in GitHub", d.Id())
-		d.SetId("")
-	} else if !ok {
-		if err = d.Set("updated_at", secret.UpdatedAt.String()); err != nil {
-			return err
-		}
-	}
-
-	return nil
+	return checkSecretDrift(d, secret.UpdatedAt.String())
 }
 
 func resourceGithubCodespacesSecretDelete(d *schema.ResourceData, meta interface{}) error {
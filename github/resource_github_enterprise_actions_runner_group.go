@@ -68,10 +68,13 @@ func resourceGithubActionsEnterpriseRunnerGroup() *schema.Resource {
 				Description: "If 'true', the runner group will be restricted to running only the workflows specified in the 'selected_workflows' array. Defaults to 'false'.",
 			},
 			"selected_workflows": {
-				Type:        schema.TypeList,
-				Elem:        &schema.Schema{Type: schema.TypeString},
+				Type: schema.TypeList,
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: toDiagFunc(validation.StringMatch(selectedWorkflowRegexp, "must be OWNER/REPOSITORY/.github/workflows/FILE.yml@REF"), "selected_workflows"),
+				},
 				Optional:    true,
-				Description: "List of workflows the runner group should be allowed to run. This setting will be ignored unless restricted_to_workflows is set to 'true'.",
+				Description: "List of workflows the runner group should be allowed to run, each as 'OWNER/REPOSITORY/.github/workflows/FILE.yml@REF'. The workflows must exist at their ref. This setting will be ignored unless restricted_to_workflows is set to 'true'.",
 			},
 			"selected_organization_ids": {
 				Type: schema.TypeSet,
@@ -124,6 +127,12 @@ func resourceGithubActionsEnterpriseRunnerGroupCreate(d *schema.ResourceData, me
 
 	ctx := context.Background()
 
+	if restrictedToWorkflows && d.HasChange("selected_workflows") {
+		if err := checkSelectedWorkflowsExist(ctx, client, meta.(*Owner).name, selectedWorkflows); err != nil {
+			return err
+		}
+	}
+
 	enterpriseRunnerGroup, resp, err := client.Enterprise.CreateEnterpriseRunnerGroup(ctx,
 		enterpriseSlug,
 		github.CreateEnterpriseRunnerGroupRequest{
@@ -307,6 +316,12 @@ func resourceGithubActionsEnterpriseRunnerGroupUpdate(d *schema.ResourceData, me
 	}
 	ctx := context.WithValue(context.Background(), ctxId, d.Id())
 
+	if restrictedToWorkflows && d.HasChange("selected_workflows") {
+		if err := checkSelectedWorkflowsExist(ctx, client, meta.(*Owner).name, selectedWorkflows); err != nil {
+			return err
+		}
+	}
+
 	if _, _, err := client.Enterprise.UpdateEnterpriseRunnerGroup(ctx, enterpriseSlug, runnerGroupID, options); err != nil {
 		return err
 	}
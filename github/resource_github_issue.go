@@ -2,9 +2,12 @@ package github
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/google/go-github/v67/github"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -17,7 +20,7 @@ func resourceGithubIssue() *schema.Resource {
 		Update: resourceGithubIssueCreateOrUpdate,
 		Delete: resourceGithubIssueDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceGithubIssueImport,
 		},
 		Schema: map[string]*schema.Schema{
 			"repository": {
@@ -61,6 +64,43 @@ func resourceGithubIssue() *schema.Resource {
 				Optional:    true,
 				Description: "Milestone number to assign to the issue.",
 			},
+			"issue_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The name of the issue type of the issue.",
+			},
+			"parent_issue_number": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "The number of the issue of the same repository this issue is a sub-issue of. Parents in other repositories are not tracked.",
+			},
+			"state": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ValidateDiagFunc: validateValueFunc([]string{"open", "closed"}),
+				Description:      "The state of the issue. Must be one of 'open' or 'closed'. Left as it is when not set.",
+			},
+			"state_reason": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ValidateDiagFunc: validateValueFunc([]string{"completed", "not_planned", "reopened"}),
+				Description:      "The reason for the state of the issue. Must be one of 'completed', 'not_planned' or 'reopened'.",
+			},
+			"locked": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the conversation of the issue is locked. Left as it is when not set.",
+			},
+			"lock_reason": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ValidateDiagFunc: validateValueFunc([]string{"off-topic", "too heated", "resolved", "spam"}),
+				Description:      "The reason the conversation is locked. Must be one of 'off-topic', 'too heated', 'resolved' or 'spam'.",
+			},
 			"issue_id": {
 				Type:        schema.TypeInt,
 				Computed:    true,
@@ -100,21 +140,49 @@ func resourceGithubIssueCreateOrUpdate(d *schema.ResourceData, meta interface{})
 		req.Milestone = intPtr(milestone)
 	}
 
-	var issue *github.Issue
+	typedReq := &issueRequestWithType{IssueRequest: req}
+	if v, ok := d.GetOk("issue_type"); ok {
+		typedReq.Type, _ = json.Marshal(v.(string))
+	} else if d.HasChange("issue_type") {
+		typedReq.Type = json.RawMessage("null")
+	}
+
+	var issue *issueWithType
 	var resp *github.Response
 	var err error
 	if d.IsNewResource() {
 		log.Printf("[DEBUG] Creating issue: %s (%s/%s)",
 			title, orgName, repoName)
-		issue, resp, err = client.Issues.Create(ctx, orgName, repoName, req)
+		issue, resp, err = createOrEditIssue(ctx, client, "POST", fmt.Sprintf("repos/%v/%v/issues", orgName, repoName), typedReq)
 		if resp != nil {
 			log.Printf("[DEBUG] Response from creating issue: %#v", *resp)
 		}
+		// The issue is tracked right away, so that it is not left behind when
+		// the requests that follow fail.
+		if err == nil {
+			d.SetId(buildTwoPartID(repoName, strconv.Itoa(issue.GetNumber())))
+		}
+		// Issues are always created open.
+		if err == nil && d.Get("state").(string) == "closed" {
+			closeReq := &github.IssueRequest{State: github.String("closed")}
+			if v, ok := d.GetOk("state_reason"); ok {
+				closeReq.StateReason = github.String(v.(string))
+			}
+			_, _, err = client.Issues.Edit(ctx, orgName, repoName, issue.GetNumber(), closeReq)
+		}
 	} else {
 		number := d.Get("number").(int)
+		if d.HasChanges("state", "state_reason") {
+			req.State = github.String(d.Get("state").(string))
+		}
+		if d.HasChange("state_reason") {
+			if v, ok := d.GetOk("state_reason"); ok {
+				req.StateReason = github.String(v.(string))
+			}
+		}
 		log.Printf("[DEBUG] Updating issue: %d:%s (%s/%s)",
 			number, title, orgName, repoName)
-		issue, resp, err = client.Issues.Edit(ctx, orgName, repoName, number, req)
+		issue, resp, err = createOrEditIssue(ctx, client, "PATCH", fmt.Sprintf("repos/%v/%v/issues/%d", orgName, repoName, number), typedReq)
 		if resp != nil {
 			log.Printf("[DEBUG] Response from updating issue: %#v", *resp)
 		}
@@ -127,6 +195,31 @@ func resourceGithubIssueCreateOrUpdate(d *schema.ResourceData, meta interface{})
 	if err = d.Set("issue_id", issue.GetID()); err != nil {
 		return err
 	}
+
+	if d.HasChange("parent_issue_number") {
+		o, n := d.GetChange("parent_issue_number")
+		if parent := n.(int); parent > 0 {
+			err = addSubIssue(ctx, client, orgName, repoName, parent, issue.GetID())
+		} else if parent := o.(int); parent > 0 {
+			err = removeSubIssue(ctx, client, orgName, repoName, parent, issue.GetID())
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if d.HasChanges("locked", "lock_reason") {
+		if d.Get("locked").(bool) {
+			opts := &github.LockIssueOptions{LockReason: d.Get("lock_reason").(string)}
+			_, err = client.Issues.Lock(ctx, orgName, repoName, issue.GetNumber(), opts)
+		} else if !d.IsNewResource() {
+			_, err = client.Issues.Unlock(ctx, orgName, repoName, issue.GetNumber())
+		}
+		if err != nil {
+			return err
+		}
+	}
+
 	return resourceGithubIssueRead(d, meta)
 }
 
@@ -149,8 +242,7 @@ func resourceGithubIssueRead(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	log.Printf("[DEBUG] Reading issue: %d (%s/%s)", number, orgName, repoName)
-	issue, resp, err := client.Issues.Get(ctx,
-		orgName, repoName, number)
+	issue, resp, err := createOrEditIssue(ctx, client, "GET", fmt.Sprintf("repos/%v/%v/issues/%d", orgName, repoName, number), nil)
 	if err != nil {
 		if ghErr, ok := err.(*github.ErrorResponse); ok {
 			if ghErr.Response.StatusCode == http.StatusNotModified {
@@ -201,12 +293,65 @@ func resourceGithubIssueRead(d *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 
+	if err = d.Set("issue_type", issue.Type.GetName()); err != nil {
+		return err
+	}
+	if err = d.Set("state", issue.GetState()); err != nil {
+		return err
+	}
+	if err = d.Set("state_reason", issue.GetStateReason()); err != nil {
+		return err
+	}
+	if err = d.Set("locked", issue.GetLocked()); err != nil {
+		return err
+	}
+	if err = d.Set("lock_reason", issue.GetActiveLockReason()); err != nil {
+		return err
+	}
+
+	// The parent is only refreshed when it is managed, as it takes another
+	// request for each issue.
+	if d.Get("parent_issue_number").(int) > 0 {
+		parent, err := getParentIssueNumber(context.WithValue(context.Background(), ctxId, d.Id()), client, orgName, repoName, number)
+		if err != nil {
+			return err
+		}
+		if err = d.Set("parent_issue_number", parent); err != nil {
+			return err
+		}
+	}
+
 	if err = d.Set("issue_id", issue.GetID()); err != nil {
 		return err
 	}
 	return nil
 }
 
+func resourceGithubIssueImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	repoName, idNumber, err := parseTwoPartID(d.Id(), "repository", "issue_number")
+	if err != nil {
+		return nil, err
+	}
+
+	number, err := strconv.Atoi(idNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+
+	parent, err := getParentIssueNumber(context.WithValue(ctx, ctxId, d.Id()), client, orgName, repoName, number)
+	if err != nil {
+		return nil, err
+	}
+	if err = d.Set("parent_issue_number", parent); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
 func resourceGithubIssueDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*Owner).v3client
 
@@ -227,3 +372,90 @@ func resourceGithubIssueDelete(d *schema.ResourceData, meta interface{}) error {
 func intPtr(i int) *int {
 	return &i
 }
+
+// issueWithType is an issue with its issue type, which go-github does not
+// model yet.
+type issueWithType struct {
+	github.Issue
+	Type *issueType `json:"type,omitempty"`
+}
+
+// issueRequestWithType is an issue request setting the issue type. Type is
+// the JSON encoded name of the type, or null to remove the type.
+type issueRequestWithType struct {
+	*github.IssueRequest
+	Type json.RawMessage `json:"type,omitempty"`
+}
+
+func createOrEditIssue(ctx context.Context, client *github.Client, method, u string, body interface{}) (*issueWithType, *github.Response, error) {
+	req, err := client.NewRequest(method, u, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	issue := new(issueWithType)
+	resp, err := client.Do(ctx, req, issue)
+	if err != nil {
+		return nil, resp, err
+	}
+	return issue, resp, nil
+}
+
+// getParentIssue returns the issue an issue is a sub-issue of, or nil.
+func getParentIssue(ctx context.Context, client *github.Client, owner, repo string, number int) (*github.Issue, error) {
+	req, err := client.NewRequest("GET", fmt.Sprintf("repos/%v/%v/issues/%d/parent", owner, repo, number), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	parent := new(github.Issue)
+	if _, err = client.Do(ctx, req, parent); err != nil {
+		if ghErr, ok := err.(*github.ErrorResponse); ok && ghErr.Response.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return parent, nil
+}
+
+// getParentIssueNumber returns the number of the issue an issue is a sub-issue
+// of, or 0. A parent in another repository is ignored, as its number would
+// refer to an issue of the same repository.
+func getParentIssueNumber(ctx context.Context, client *github.Client, owner, repo string, number int) (int, error) {
+	parent, err := getParentIssue(ctx, client, owner, repo, number)
+	if err != nil || parent == nil {
+		return 0, err
+	}
+
+	if !strings.HasSuffix(strings.ToLower(parent.GetRepositoryURL()), strings.ToLower(fmt.Sprintf("/repos/%s/%s", owner, repo))) {
+		log.Printf("[WARN] Ignoring parent issue %d of issue %d (%s/%s) because it belongs to another repository: %s",
+			parent.GetNumber(), number, owner, repo, parent.GetRepositoryURL())
+		return 0, nil
+	}
+	return parent.GetNumber(), nil
+}
+
+func addSubIssue(ctx context.Context, client *github.Client, owner, repo string, parent int, subIssueID int64) error {
+	body := map[string]interface{}{
+		"sub_issue_id":   subIssueID,
+		"replace_parent": true,
+	}
+	req, err := client.NewRequest("POST", fmt.Sprintf("repos/%v/%v/issues/%d/sub_issues", owner, repo, parent), body)
+	if err != nil {
+		return err
+	}
+	_, err = client.Do(ctx, req, nil)
+	return err
+}
+
+func removeSubIssue(ctx context.Context, client *github.Client, owner, repo string, parent int, subIssueID int64) error {
+	body := map[string]interface{}{
+		"sub_issue_id": subIssueID,
+	}
+	req, err := client.NewRequest("DELETE", fmt.Sprintf("repos/%v/%v/issues/%d/sub_issue", owner, repo, parent), body)
+	if err != nil {
+		return err
+	}
+	_, err = client.Do(ctx, req, nil)
+	return err
+}
@@ -1,12 +1,16 @@
 package github
 
 import (
+	"encoding/json"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"net/url"
 	"testing"
 
+	"github.com/google/go-github/v67/github"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func TestAccGithubIssue(t *testing.T) {
@@ -174,3 +178,145 @@ func TestAccGithubIssue(t *testing.T) {
 	})
 
 }
+
+func TestIssueRequestWithType(t *testing.T) {
+	cases := []struct {
+		name     string
+		typ      json.RawMessage
+		expected string
+	}{
+		{
+			name:     "unchanged",
+			expected: `{"title":"hello"}`,
+		},
+		{
+			name:     "set",
+			typ:      json.RawMessage(`"Bug"`),
+			expected: `{"title":"hello","type":"Bug"}`,
+		},
+		{
+			name:     "removed",
+			typ:      json.RawMessage("null"),
+			expected: `{"title":"hello","type":null}`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := &issueRequestWithType{
+				IssueRequest: &github.IssueRequest{Title: github.String("hello")},
+				Type:         tc.typ,
+			}
+			b, err := json.Marshal(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(b) != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, b)
+			}
+		})
+	}
+}
+
+func TestResourceGithubIssueCreateClosed(t *testing.T) {
+	ts := githubApiMock([]*mockResponse{
+		{
+			ExpectedUri:    "/repos/octo/hello/issues",
+			ExpectedMethod: "POST",
+			ResponseBody:   `{"id": 70, "number": 7, "state": "open", "title": "Bug"}`,
+			StatusCode:     201,
+		},
+		{
+			ExpectedUri:    "/repos/octo/hello/issues/7",
+			ExpectedMethod: "PATCH",
+			ExpectedBody:   []byte(`{"state":"closed"}` + "\n"),
+			ResponseBody:   `{"message": "Server Error"}`,
+			StatusCode:     500,
+		},
+	})
+	defer ts.Close()
+
+	client := github.NewClient(nil)
+	client.BaseURL, _ = url.Parse(ts.URL + "/")
+	meta := &Owner{name: "octo", v3client: client}
+
+	d := schema.TestResourceDataRaw(t, resourceGithubIssue().Schema, map[string]interface{}{
+		"repository": "hello",
+		"title":      "Bug",
+		"state":      "closed",
+	})
+	d.MarkNewResource()
+
+	if err := resourceGithubIssueCreateOrUpdate(d, meta); err == nil {
+		t.Fatal("Expected an error, got none")
+	}
+	if d.Id() != "hello:7" {
+		t.Errorf("Expected the issue to be tracked when closing it fails, got ID %q", d.Id())
+	}
+}
+
+func TestResourceGithubIssueRead_parent(t *testing.T) {
+	issue := `{"id": 70, "number": 7, "state": "open", "title": "Bug", "locked": true, "active_lock_reason": "resolved"}`
+
+	t.Run("does not look the parent up when not managed", func(t *testing.T) {
+		ts := githubApiMock([]*mockResponse{
+			{
+				ExpectedUri:  "/repos/octo/hello/issues/7",
+				ResponseBody: issue,
+				StatusCode:   200,
+			},
+		})
+		defer ts.Close()
+
+		client := github.NewClient(nil)
+		client.BaseURL, _ = url.Parse(ts.URL + "/")
+		meta := &Owner{name: "octo", v3client: client}
+
+		d := schema.TestResourceDataRaw(t, resourceGithubIssue().Schema, map[string]interface{}{
+			"repository": "hello",
+			"title":      "Bug",
+		})
+		d.SetId("hello:7")
+
+		if err := resourceGithubIssueRead(d, meta); err != nil {
+			t.Fatal(err)
+		}
+		if reason := d.Get("lock_reason").(string); reason != "resolved" {
+			t.Errorf("Expected the lock reason to be resolved, got %q", reason)
+		}
+	})
+
+	t.Run("ignores a parent in another repository", func(t *testing.T) {
+		ts := githubApiMock([]*mockResponse{
+			{
+				ExpectedUri:  "/repos/octo/hello/issues/7",
+				ResponseBody: issue,
+				StatusCode:   200,
+			},
+			{
+				ExpectedUri:  "/repos/octo/hello/issues/7/parent",
+				ResponseBody: `{"id": 30, "number": 3, "repository_url": "https://api.github.com/repos/octo/world"}`,
+				StatusCode:   200,
+			},
+		})
+		defer ts.Close()
+
+		client := github.NewClient(nil)
+		client.BaseURL, _ = url.Parse(ts.URL + "/")
+		meta := &Owner{name: "octo", v3client: client}
+
+		d := schema.TestResourceDataRaw(t, resourceGithubIssue().Schema, map[string]interface{}{
+			"repository":          "hello",
+			"title":               "Bug",
+			"parent_issue_number": 3,
+		})
+		d.SetId("hello:7")
+
+		if err := resourceGithubIssueRead(d, meta); err != nil {
+			t.Fatal(err)
+		}
+		if parent := d.Get("parent_issue_number").(int); parent != 0 {
+			t.Errorf("Expected the parent in another repository to be ignored, got %d", parent)
+		}
+	})
+}
@@ -0,0 +1,273 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceGithubOrganizationCodeSecurityConfiguration() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGithubOrganizationCodeSecurityConfigurationCreate,
+		Read:   resourceGithubOrganizationCodeSecurityConfigurationRead,
+		Update: resourceGithubOrganizationCodeSecurityConfigurationUpdate,
+		Delete: resourceGithubOrganizationCodeSecurityConfigurationDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the code security configuration. Must be unique within the organization.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "A description of the code security configuration.",
+			},
+			"advanced_security":                  codeSecurityFeatureSchema("The enablement status of GitHub Advanced Security.", "disabled"),
+			"dependency_graph":                   codeSecurityFeatureSchema("The enablement status of Dependency Graph.", "enabled"),
+			"dependency_graph_autosubmit_action": codeSecurityFeatureSchema("The enablement status of Automatic dependency submission.", "disabled"),
+			"dependency_graph_autosubmit_action_labeled_runners": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to use runners labeled with 'dependency-submission' for Automatic dependency submission, or GitHub-hosted runners.",
+			},
+			"dependabot_alerts":                     codeSecurityFeatureSchema("The enablement status of Dependabot alerts.", "disabled"),
+			"dependabot_security_updates":           codeSecurityFeatureSchema("The enablement status of Dependabot security updates.", "disabled"),
+			"code_scanning_default_setup":           codeSecurityFeatureSchema("The enablement status of code scanning default setup.", "disabled"),
+			"secret_scanning":                       codeSecurityFeatureSchema("The enablement status of secret scanning.", "disabled"),
+			"secret_scanning_push_protection":       codeSecurityFeatureSchema("The enablement status of secret scanning push protection.", "disabled"),
+			"secret_scanning_validity_checks":       codeSecurityFeatureSchema("The enablement status of secret scanning validity checks.", "disabled"),
+			"secret_scanning_non_provider_patterns": codeSecurityFeatureSchema("The enablement status of secret scanning non-provider patterns.", "disabled"),
+			"private_vulnerability_reporting":       codeSecurityFeatureSchema("The enablement status of private vulnerability reporting.", "disabled"),
+			"enforcement": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "enforced",
+				ValidateDiagFunc: validateValueFunc([]string{"enforced", "unenforced"}),
+				Description:      "Whether repositories can change the settings of the configuration. Must be one of 'enforced' or 'unenforced'.",
+			},
+			"default_for_new_repos": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "none",
+				ValidateDiagFunc: validateValueFunc([]string{"all", "none", "private_and_internal", "public"}),
+				Description:      "The new repositories the configuration is applied to by default. Must be one of 'all', 'none', 'private_and_internal' or 'public'.",
+			},
+			"target_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The type of the code security configuration.",
+			},
+			"html_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URL of the configuration on GitHub.",
+			},
+		},
+	}
+}
+
+func codeSecurityFeatureSchema(description, defaultValue string) *schema.Schema {
+	return &schema.Schema{
+		Type:             schema.TypeString,
+		Optional:         true,
+		Default:          defaultValue,
+		ValidateDiagFunc: validateValueFunc([]string{"enabled", "disabled", "not_set"}),
+		Description:      fmt.Sprintf("%s Must be one of 'enabled', 'disabled' or 'not_set'. Defaults to '%s'.", description, defaultValue),
+	}
+}
+
+func resourceGithubOrganizationCodeSecurityConfigurationCreate(d *schema.ResourceData, meta interface{}) error {
+	err := checkOrganization(meta)
+	if err != nil {
+		return err
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+	ctx := context.Background()
+
+	configuration, _, err := client.Organizations.CreateCodeSecurityConfiguration(ctx, orgName, expandCodeSecurityConfiguration(d))
+	if err != nil {
+		return err
+	}
+	d.SetId(strconv.FormatInt(configuration.GetID(), 10))
+
+	if defaultForNewRepos := d.Get("default_for_new_repos").(string); defaultForNewRepos != "none" {
+		if _, _, err = client.Organizations.SetDefaultCodeSecurityConfiguration(ctx, orgName, configuration.GetID(), defaultForNewRepos); err != nil {
+			return err
+		}
+	}
+
+	return resourceGithubOrganizationCodeSecurityConfigurationRead(d, meta)
+}
+
+func resourceGithubOrganizationCodeSecurityConfigurationRead(d *schema.ResourceData, meta interface{}) error {
+	err := checkOrganization(meta)
+	if err != nil {
+		return err
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return unconvertibleIdErr(d.Id(), err)
+	}
+
+	configuration, _, err := client.Organizations.GetCodeSecurityConfiguration(ctx, orgName, id)
+	if err != nil {
+		if ghErr, ok := err.(*github.ErrorResponse); ok {
+			if ghErr.Response.StatusCode == http.StatusNotFound {
+				log.Printf("[INFO] Removing code security configuration %s/%s from state because it no longer exists in GitHub",
+					orgName, d.Id())
+				d.SetId("")
+				return nil
+			}
+		}
+		return err
+	}
+
+	defaultForNewRepos, err := getCodeSecurityConfigurationDefaultForNewRepos(ctx, client, orgName, id)
+	if err != nil {
+		return err
+	}
+
+	values := map[string]interface{}{
+		"name":                                  configuration.GetName(),
+		"description":                           configuration.GetDescription(),
+		"advanced_security":                     configuration.GetAdvancedSecurity(),
+		"dependency_graph":                      configuration.GetDependencyGraph(),
+		"dependency_graph_autosubmit_action":    configuration.GetDependencyGraphAutosubmitAction(),
+		"dependabot_alerts":                     configuration.GetDependabotAlerts(),
+		"dependabot_security_updates":           configuration.GetDependabotSecurityUpdates(),
+		"code_scanning_default_setup":           configuration.GetCodeScanningDefaultSetup(),
+		"secret_scanning":                       configuration.GetSecretScanning(),
+		"secret_scanning_push_protection":       configuration.GetSecretScanningPushProtection(),
+		"secret_scanning_validity_checks":       configuration.GetSecretScanningValidityChecks(),
+		"secret_scanning_non_provider_patterns": configuration.GetSecretScanningNonProviderPatterns(),
+		"private_vulnerability_reporting":       configuration.GetPrivateVulnerabilityReporting(),
+		"enforcement":                           configuration.GetEnforcement(),
+		"default_for_new_repos":                 defaultForNewRepos,
+		"target_type":                           configuration.GetTargetType(),
+		"html_url":                              configuration.GetHTMLURL(),
+		"dependency_graph_autosubmit_action_labeled_runners": configuration.GetDependencyGraphAutosubmitActionOptions().GetLabeledRunners(),
+	}
+	for k, v := range values {
+		if err = d.Set(k, v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func resourceGithubOrganizationCodeSecurityConfigurationUpdate(d *schema.ResourceData, meta interface{}) error {
+	err := checkOrganization(meta)
+	if err != nil {
+		return err
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return unconvertibleIdErr(d.Id(), err)
+	}
+
+	if _, _, err = client.Organizations.UpdateCodeSecurityConfiguration(ctx, orgName, id, expandCodeSecurityConfiguration(d)); err != nil {
+		return err
+	}
+
+	if d.HasChange("default_for_new_repos") {
+		if _, _, err = client.Organizations.SetDefaultCodeSecurityConfiguration(ctx, orgName, id, d.Get("default_for_new_repos").(string)); err != nil {
+			return err
+		}
+	}
+
+	return resourceGithubOrganizationCodeSecurityConfigurationRead(d, meta)
+}
+
+func resourceGithubOrganizationCodeSecurityConfigurationDelete(d *schema.ResourceData, meta interface{}) error {
+	err := checkOrganization(meta)
+	if err != nil {
+		return err
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return unconvertibleIdErr(d.Id(), err)
+	}
+
+	// Default configurations cannot be deleted.
+	if d.Get("default_for_new_repos").(string) != "none" {
+		if _, _, err = client.Organizations.SetDefaultCodeSecurityConfiguration(ctx, orgName, id, "none"); err != nil {
+			return err
+		}
+	}
+
+	_, err = client.Organizations.DeleteCodeSecurityConfiguration(ctx, orgName, id)
+	return err
+}
+
+func expandCodeSecurityConfiguration(d *schema.ResourceData) *github.CodeSecurityConfiguration {
+	return &github.CodeSecurityConfiguration{
+		Name:                            github.String(d.Get("name").(string)),
+		Description:                     github.String(d.Get("description").(string)),
+		AdvancedSecurity:                github.String(d.Get("advanced_security").(string)),
+		DependencyGraph:                 github.String(d.Get("dependency_graph").(string)),
+		DependencyGraphAutosubmitAction: github.String(d.Get("dependency_graph_autosubmit_action").(string)),
+		DependencyGraphAutosubmitActionOptions: &github.DependencyGraphAutosubmitActionOptions{
+			LabeledRunners: github.Bool(d.Get("dependency_graph_autosubmit_action_labeled_runners").(bool)),
+		},
+		DependabotAlerts:                  github.String(d.Get("dependabot_alerts").(string)),
+		DependabotSecurityUpdates:         github.String(d.Get("dependabot_security_updates").(string)),
+		CodeScanningDefaultSetup:          github.String(d.Get("code_scanning_default_setup").(string)),
+		SecretScanning:                    github.String(d.Get("secret_scanning").(string)),
+		SecretScanningPushProtection:      github.String(d.Get("secret_scanning_push_protection").(string)),
+		SecretScanningValidityChecks:      github.String(d.Get("secret_scanning_validity_checks").(string)),
+		SecretScanningNonProviderPatterns: github.String(d.Get("secret_scanning_non_provider_patterns").(string)),
+		PrivateVulnerabilityReporting:     github.String(d.Get("private_vulnerability_reporting").(string)),
+		Enforcement:                       github.String(d.Get("enforcement").(string)),
+	}
+}
+
+// getCodeSecurityConfigurationDefaultForNewRepos returns the new repositories
+// a configuration applies to by default. The endpoint is requested directly
+// as go-github does not decode the default_for_new_repos of its response.
+func getCodeSecurityConfigurationDefaultForNewRepos(ctx context.Context, client *github.Client, org string, id int64) (string, error) {
+	req, err := client.NewRequest("GET", fmt.Sprintf("orgs/%v/code-security/configurations/defaults", org), nil)
+	if err != nil {
+		return "", err
+	}
+
+	var defaults []*github.CodeSecurityConfigurationWithDefaultForNewRepos
+	if _, err = client.Do(ctx, req, &defaults); err != nil {
+		return "", err
+	}
+
+	for _, d := range defaults {
+		if d.GetConfiguration().GetID() == id {
+			return d.GetDefaultForNewRepos(), nil
+		}
+	}
+	return "none", nil
+}
@@ -0,0 +1,364 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceGithubOrganizationCodeSecurityConfigurationAttachment() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGithubOrganizationCodeSecurityConfigurationAttachmentCreate,
+		Read:   resourceGithubOrganizationCodeSecurityConfigurationAttachmentRead,
+		Update: resourceGithubOrganizationCodeSecurityConfigurationAttachmentUpdate,
+		Delete: resourceGithubOrganizationCodeSecurityConfigurationAttachmentDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceGithubOrganizationCodeSecurityConfigurationAttachmentImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"configuration_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the code security configuration to attach.",
+			},
+			"scope": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateValueFunc([]string{"all", "all_without_configurations", "public", "private_or_internal", "selected"}),
+				Description:      "The repositories to attach the configuration to. Must be one of 'all', 'all_without_configurations', 'public', 'private_or_internal' or 'selected'. 'selected_repository_ids' is required if set to 'selected'. No drift is detected with 'all_without_configurations'.",
+			},
+			"selected_repository_ids": {
+				Type: schema.TypeSet,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Set:         schema.HashInt,
+				Optional:    true,
+				Description: "An array of repository IDs to attach the configuration to.",
+			},
+			"attached_repository_ids": {
+				Type: schema.TypeSet,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Set:         schema.HashInt,
+				Computed:    true,
+				Description: "The IDs of the repositories the configuration is attached to.",
+			},
+		},
+	}
+}
+
+// codeSecurityConfigurationRepository is an item of the repositories attached
+// to a code security configuration, which go-github decodes as a repository.
+type codeSecurityConfigurationRepository struct {
+	Status     string             `json:"status"`
+	Repository *github.Repository `json:"repository"`
+}
+
+func resourceGithubOrganizationCodeSecurityConfigurationAttachmentCreate(d *schema.ResourceData, meta interface{}) error {
+	err := checkOrganization(meta)
+	if err != nil {
+		return err
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+	ctx := context.Background()
+
+	configurationID := d.Get("configuration_id").(string)
+	id, err := strconv.ParseInt(configurationID, 10, 64)
+	if err != nil {
+		return unconvertibleIdErr(configurationID, err)
+	}
+
+	scope := d.Get("scope").(string)
+	selectedRepositoryIDs := expandCodeSecurityRepositoryIDs(d.Get("selected_repository_ids").(*schema.Set))
+	if scope == "selected" && len(selectedRepositoryIDs) == 0 {
+		return fmt.Errorf("selected_repository_ids is required when scope is set to selected")
+	}
+	if scope != "selected" && len(selectedRepositoryIDs) > 0 {
+		return fmt.Errorf("cannot use selected_repository_ids without scope being set to selected")
+	}
+
+	if _, err = client.Organizations.AttachCodeSecurityConfigurationsToRepositories(ctx, orgName, id, scope, selectedRepositoryIDs); err != nil {
+		return err
+	}
+	d.SetId(buildTwoPartID(configurationID, scope))
+
+	return resourceGithubOrganizationCodeSecurityConfigurationAttachmentRead(d, meta)
+}
+
+func resourceGithubOrganizationCodeSecurityConfigurationAttachmentRead(d *schema.ResourceData, meta interface{}) error {
+	err := checkOrganization(meta)
+	if err != nil {
+		return err
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+
+	configurationID, scope, err := parseTwoPartID(d.Id(), "configuration_id", "scope")
+	if err != nil {
+		return err
+	}
+	id, err := strconv.ParseInt(configurationID, 10, 64)
+	if err != nil {
+		return unconvertibleIdErr(configurationID, err)
+	}
+
+	attached, err := listCodeSecurityConfigurationRepositoryIDs(ctx, client, orgName, id)
+	if err != nil {
+		if ghErr, ok := err.(*github.ErrorResponse); ok {
+			if ghErr.Response.StatusCode == http.StatusNotFound {
+				log.Printf("[INFO] Removing code security configuration attachment %s/%s from state because the configuration no longer exists in GitHub",
+					orgName, d.Id())
+				d.SetId("")
+				return nil
+			}
+		}
+		return err
+	}
+
+	switch scope {
+	case "selected":
+		// Only the repositories of the attachment are tracked, as others may
+		// have been attached by another attachment or in the UI. Every
+		// attached repository is tracked when importing.
+		selected := attached
+		if ids := d.Get("selected_repository_ids").(*schema.Set); ids.Len() > 0 {
+			selected = intersectCodeSecurityRepositoryIDs(attached, expandCodeSecurityRepositoryIDs(ids))
+		}
+		if err = d.Set("selected_repository_ids", selected); err != nil {
+			return err
+		}
+	case "all", "public", "private_or_internal":
+		// Repositories created or detached since the configuration was
+		// attached are not covered by it. The attachment is then recreated
+		// to cover them again.
+		missing, err := listCodeSecurityConfigurationMissingRepositoryIDs(ctx, client, orgName, scope, attached)
+		if err != nil {
+			return err
+		}
+		if len(missing) > 0 {
+			log.Printf("[INFO] Removing code security configuration attachment %s/%s from state because %d repositories are no longer attached",
+				orgName, d.Id(), len(missing))
+			d.SetId("")
+			return nil
+		}
+	}
+
+	if err = d.Set("configuration_id", configurationID); err != nil {
+		return err
+	}
+	if err = d.Set("scope", scope); err != nil {
+		return err
+	}
+	if err = d.Set("attached_repository_ids", attached); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceGithubOrganizationCodeSecurityConfigurationAttachmentUpdate(d *schema.ResourceData, meta interface{}) error {
+	err := checkOrganization(meta)
+	if err != nil {
+		return err
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+
+	configurationID := d.Get("configuration_id").(string)
+	id, err := strconv.ParseInt(configurationID, 10, 64)
+	if err != nil {
+		return unconvertibleIdErr(configurationID, err)
+	}
+
+	if d.HasChange("selected_repository_ids") {
+		if d.Get("scope").(string) != "selected" {
+			return fmt.Errorf("cannot use selected_repository_ids without scope being set to selected")
+		}
+
+		o, n := d.GetChange("selected_repository_ids")
+		oldIDs := o.(*schema.Set)
+		newIDs := n.(*schema.Set)
+
+		if added := expandCodeSecurityRepositoryIDs(newIDs.Difference(oldIDs)); len(added) > 0 {
+			if _, err = client.Organizations.AttachCodeSecurityConfigurationsToRepositories(ctx, orgName, id, "selected", added); err != nil {
+				return err
+			}
+		}
+		if removed := expandCodeSecurityRepositoryIDs(oldIDs.Difference(newIDs)); len(removed) > 0 {
+			if _, err = client.Organizations.DetachCodeSecurityConfigurationsFromRepositories(ctx, orgName, removed); err != nil {
+				return err
+			}
+		}
+	}
+
+	return resourceGithubOrganizationCodeSecurityConfigurationAttachmentRead(d, meta)
+}
+
+func resourceGithubOrganizationCodeSecurityConfigurationAttachmentDelete(d *schema.ResourceData, meta interface{}) error {
+	err := checkOrganization(meta)
+	if err != nil {
+		return err
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+
+	configurationID := d.Get("configuration_id").(string)
+	id, err := strconv.ParseInt(configurationID, 10, 64)
+	if err != nil {
+		return unconvertibleIdErr(configurationID, err)
+	}
+
+	attached, err := listCodeSecurityConfigurationRepositoryIDs(ctx, client, orgName, id)
+	if err != nil {
+		return err
+	}
+
+	// With the selected scope, only the repositories of the attachment are
+	// detached, and only while they are still attached to the configuration,
+	// as detaching removes whichever configuration a repository has.
+	if d.Get("scope").(string) == "selected" {
+		attached = intersectCodeSecurityRepositoryIDs(attached, expandCodeSecurityRepositoryIDs(d.Get("selected_repository_ids").(*schema.Set)))
+	}
+	if len(attached) == 0 {
+		return nil
+	}
+
+	_, err = client.Organizations.DetachCodeSecurityConfigurationsFromRepositories(ctx, orgName, attached)
+	return err
+}
+
+func resourceGithubOrganizationCodeSecurityConfigurationAttachmentImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	configurationID, scope, err := parseTwoPartID(d.Id(), "configuration_id", "scope")
+	if err != nil {
+		return nil, err
+	}
+
+	if err = d.Set("configuration_id", configurationID); err != nil {
+		return nil, err
+	}
+	if err = d.Set("scope", scope); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// listCodeSecurityConfigurationRepositoryIDs returns the sorted IDs of the
+// repositories a configuration is attached to, or being attached to. The
+// endpoint is requested directly as go-github decodes its items as
+// repositories and does not follow its cursor pagination.
+func listCodeSecurityConfigurationRepositoryIDs(ctx context.Context, client *github.Client, org string, id int64) ([]int64, error) {
+	query := url.Values{"per_page": {"100"}}
+	ids := []int64{}
+
+	for {
+		u := fmt.Sprintf("orgs/%v/code-security/configurations/%v/repositories?%s", org, id, query.Encode())
+		req, err := client.NewRequest("GET", u, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var repositories []*codeSecurityConfigurationRepository
+		resp, err := client.Do(ctx, req, &repositories)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, r := range repositories {
+			switch r.Status {
+			case "attached", "attaching", "updating", "enforced":
+				ids = append(ids, r.Repository.GetID())
+			}
+		}
+
+		if resp.After == "" {
+			break
+		}
+		query.Set("after", resp.After)
+	}
+
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids, nil
+}
+
+// listCodeSecurityConfigurationMissingRepositoryIDs returns the IDs of the
+// unarchived repositories of the scope that are not attached.
+func listCodeSecurityConfigurationMissingRepositoryIDs(ctx context.Context, client *github.Client, org, scope string, attached []int64) ([]int64, error) {
+	isAttached := make(map[int64]bool, len(attached))
+	for _, id := range attached {
+		isAttached[id] = true
+	}
+
+	opts := &github.RepositoryListByOrgOptions{
+		ListOptions: github.ListOptions{PerPage: maxPerPage},
+	}
+	missing := []int64{}
+
+	for {
+		repos, resp, err := client.Repositories.ListByOrg(ctx, org, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, repo := range repos {
+			if repo.GetArchived() || isAttached[repo.GetID()] {
+				continue
+			}
+			if scope == "public" && repo.GetVisibility() != "public" {
+				continue
+			}
+			if scope == "private_or_internal" && repo.GetVisibility() == "public" {
+				continue
+			}
+			missing = append(missing, repo.GetID())
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return missing, nil
+}
+
+func expandCodeSecurityRepositoryIDs(set *schema.Set) []int64 {
+	ids := make([]int64, 0, set.Len())
+	for _, id := range set.List() {
+		ids = append(ids, int64(id.(int)))
+	}
+	return ids
+}
+
+// intersectCodeSecurityRepositoryIDs returns the IDs of ids which are
+// attached, in the order of attached.
+func intersectCodeSecurityRepositoryIDs(attached, ids []int64) []int64 {
+	result := []int64{}
+	for _, id := range attached {
+		if slices.Contains(ids, id) {
+			result = append(result, id)
+		}
+	}
+	return result
+}
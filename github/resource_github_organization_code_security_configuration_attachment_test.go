@@ -0,0 +1,82 @@
+package github
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestResourceGithubOrganizationCodeSecurityConfigurationAttachmentDelete(t *testing.T) {
+	repositories := `[
+		{"status": "attached", "repository": {"id": 1}},
+		{"status": "attached", "repository": {"id": 2}},
+		{"status": "attached", "repository": {"id": 3}}
+	]`
+
+	t.Run("detaches the selected repositories only", func(t *testing.T) {
+		ts := githubApiMock([]*mockResponse{
+			{
+				ExpectedUri:  "/orgs/octo/code-security/configurations/17/repositories?per_page=100",
+				ResponseBody: repositories,
+				StatusCode:   200,
+			},
+			{
+				ExpectedUri:    "/orgs/octo/code-security/configurations/detach",
+				ExpectedMethod: "DELETE",
+				ExpectedBody:   []byte(`{"selected_repository_ids":[1,3]}` + "\n"),
+				StatusCode:     204,
+			},
+		})
+		defer ts.Close()
+
+		client := github.NewClient(nil)
+		client.BaseURL, _ = url.Parse(ts.URL + "/")
+		owner := &Owner{name: "octo", v3client: client, IsOrganization: true}
+
+		r := resourceGithubOrganizationCodeSecurityConfigurationAttachment()
+		d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+			"configuration_id":        "17",
+			"scope":                   "selected",
+			"selected_repository_ids": []interface{}{1, 3, 4},
+		})
+		d.SetId("17")
+
+		if err := r.Delete(d, owner); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("detaches every repository with other scopes", func(t *testing.T) {
+		ts := githubApiMock([]*mockResponse{
+			{
+				ExpectedUri:  "/orgs/octo/code-security/configurations/17/repositories?per_page=100",
+				ResponseBody: repositories,
+				StatusCode:   200,
+			},
+			{
+				ExpectedUri:    "/orgs/octo/code-security/configurations/detach",
+				ExpectedMethod: "DELETE",
+				ExpectedBody:   []byte(`{"selected_repository_ids":[1,2,3]}` + "\n"),
+				StatusCode:     204,
+			},
+		})
+		defer ts.Close()
+
+		client := github.NewClient(nil)
+		client.BaseURL, _ = url.Parse(ts.URL + "/")
+		owner := &Owner{name: "octo", v3client: client, IsOrganization: true}
+
+		r := resourceGithubOrganizationCodeSecurityConfigurationAttachment()
+		d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+			"configuration_id": "17",
+			"scope":            "public",
+		})
+		d.SetId("17")
+
+		if err := r.Delete(d, owner); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
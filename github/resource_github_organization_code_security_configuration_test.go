@@ -0,0 +1,134 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccGithubOrganizationCodeSecurityConfiguration(t *testing.T) {
+
+	randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+
+	t.Run("creates and attaches a configuration without error", func(t *testing.T) {
+
+		config := fmt.Sprintf(`
+			resource "github_repository" "test" {
+				name = "tf-acc-test-code-security-%[1]s"
+			}
+
+			resource "github_organization_code_security_configuration" "test" {
+				name                 = "tf-acc-test-%[1]s"
+				description          = "Terraform acceptance tests"
+				dependabot_alerts    = "enabled"
+				secret_scanning      = "disabled"
+				enforcement          = "unenforced"
+			}
+
+			resource "github_organization_code_security_configuration_attachment" "test" {
+				configuration_id        = github_organization_code_security_configuration.test.id
+				scope                   = "selected"
+				selected_repository_ids = [github_repository.test.repo_id]
+			}
+		`, randomID)
+
+		checks := map[string]resource.TestCheckFunc{
+			"before": resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr(
+					"github_organization_code_security_configuration.test", "secret_scanning", "disabled",
+				),
+				resource.TestCheckResourceAttr(
+					"github_organization_code_security_configuration.test", "default_for_new_repos", "none",
+				),
+				resource.TestCheckResourceAttr(
+					"github_organization_code_security_configuration_attachment.test", "attached_repository_ids.#", "1",
+				),
+			),
+			"after": resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr(
+					"github_organization_code_security_configuration.test", "secret_scanning", "enabled",
+				),
+				resource.TestCheckResourceAttrSet(
+					"github_organization_code_security_configuration.test", "html_url",
+				),
+			),
+		}
+
+		testCase := func(t *testing.T, mode string) {
+			resource.Test(t, resource.TestCase{
+				PreCheck:  func() { skipUnlessMode(t, mode) },
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: config,
+						Check:  checks["before"],
+					},
+					{
+						Config: strings.Replace(config,
+							`secret_scanning      = "disabled"`,
+							`secret_scanning      = "enabled"`, 1),
+						Check: checks["after"],
+					},
+					{
+						ResourceName:      "github_organization_code_security_configuration.test",
+						ImportState:       true,
+						ImportStateVerify: true,
+					},
+				},
+			})
+		}
+
+		t.Run("with an anonymous account", func(t *testing.T) {
+			t.Skip("anonymous account not supported for this operation")
+		})
+
+		t.Run("with an individual account", func(t *testing.T) {
+			t.Skip("individual account not supported for this operation")
+		})
+
+		t.Run("with an organization account", func(t *testing.T) {
+			testCase(t, organization)
+		})
+	})
+}
+
+func TestListCodeSecurityConfigurationRepositoryIDs(t *testing.T) {
+	ts := githubApiMock([]*mockResponse{
+		{
+			ExpectedUri:    "/orgs/octo/code-security/configurations/17/repositories?per_page=100",
+			ExpectedMethod: "GET",
+			ResponseHeaders: map[string]string{
+				"Link": `<https://api.github.com/orgs/octo/code-security/configurations/17/repositories?per_page=100&after=MTA%3D>; rel="next"`,
+			},
+			ResponseBody: `[{"status": "attached", "repository": {"id": 3}}, {"status": "failed", "repository": {"id": 2}}]`,
+			StatusCode:   200,
+		},
+		{
+			ExpectedUri:    "/orgs/octo/code-security/configurations/17/repositories?after=MTA%3D&per_page=100",
+			ExpectedMethod: "GET",
+			ResponseBody:   `[{"status": "enforced", "repository": {"id": 1}}, {"status": "detached", "repository": {"id": 4}}]`,
+			StatusCode:     200,
+		},
+	})
+	defer ts.Close()
+
+	client := github.NewClient(nil)
+	client.BaseURL, _ = url.Parse(ts.URL + "/")
+
+	ids, err := listCodeSecurityConfigurationRepositoryIDs(context.Background(), client, "octo", 17)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	expected := []int64{1, 3}
+	if !reflect.DeepEqual(ids, expected) {
+		t.Errorf("Expected %v, got %v", expected, ids)
+	}
+}
@@ -0,0 +1,218 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// issueType is an issue type of an organization, which go-github does not
+// model yet.
+type issueType struct {
+	ID          int64   `json:"id,omitempty"`
+	NodeID      string  `json:"node_id,omitempty"`
+	Name        string  `json:"name"`
+	Description *string `json:"description"`
+	Color       *string `json:"color"`
+	IsEnabled   bool    `json:"is_enabled"`
+}
+
+func (t *issueType) GetName() string {
+	if t == nil {
+		return ""
+	}
+	return t.Name
+}
+
+func resourceGithubOrganizationIssueType() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGithubOrganizationIssueTypeCreate,
+		Read:   resourceGithubOrganizationIssueTypeRead,
+		Update: resourceGithubOrganizationIssueTypeUpdate,
+		Delete: resourceGithubOrganizationIssueTypeDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the issue type.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The description of the issue type.",
+			},
+			"color": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validateValueFunc([]string{"gray", "blue", "green", "yellow", "orange", "red", "pink", "purple"}),
+				Description:      "The color of the issue type. Must be one of 'gray', 'blue', 'green', 'yellow', 'orange', 'red', 'pink' or 'purple'.",
+			},
+			"is_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the issue type can be used in the repositories of the organization.",
+			},
+			"node_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The node ID of the issue type.",
+			},
+		},
+	}
+}
+
+func resourceGithubOrganizationIssueTypeCreate(d *schema.ResourceData, meta interface{}) error {
+	err := checkOrganization(meta)
+	if err != nil {
+		return err
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+	ctx := context.Background()
+
+	req, err := client.NewRequest("POST", fmt.Sprintf("orgs/%v/issue-types", orgName), expandIssueType(d))
+	if err != nil {
+		return err
+	}
+
+	created := new(issueType)
+	if _, err = client.Do(ctx, req, created); err != nil {
+		return err
+	}
+	d.SetId(strconv.FormatInt(created.ID, 10))
+
+	return resourceGithubOrganizationIssueTypeRead(d, meta)
+}
+
+func resourceGithubOrganizationIssueTypeRead(d *schema.ResourceData, meta interface{}) error {
+	err := checkOrganization(meta)
+	if err != nil {
+		return err
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return unconvertibleIdErr(d.Id(), err)
+	}
+
+	issueTypes, err := listOrganizationIssueTypes(ctx, client, orgName)
+	if err != nil {
+		return err
+	}
+
+	var found *issueType
+	for _, t := range issueTypes {
+		if t.ID == id {
+			found = t
+			break
+		}
+	}
+	if found == nil {
+		log.Printf("[INFO] Removing issue type %s/%s from state because it no longer exists in GitHub",
+			orgName, d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err = d.Set("name", found.Name); err != nil {
+		return err
+	}
+	if err = d.Set("description", found.Description); err != nil {
+		return err
+	}
+	if err = d.Set("color", found.Color); err != nil {
+		return err
+	}
+	if err = d.Set("is_enabled", found.IsEnabled); err != nil {
+		return err
+	}
+	if err = d.Set("node_id", found.NodeID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceGithubOrganizationIssueTypeUpdate(d *schema.ResourceData, meta interface{}) error {
+	err := checkOrganization(meta)
+	if err != nil {
+		return err
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+
+	req, err := client.NewRequest("PUT", fmt.Sprintf("orgs/%v/issue-types/%v", orgName, d.Id()), expandIssueType(d))
+	if err != nil {
+		return err
+	}
+	if _, err = client.Do(ctx, req, nil); err != nil {
+		return err
+	}
+
+	return resourceGithubOrganizationIssueTypeRead(d, meta)
+}
+
+func resourceGithubOrganizationIssueTypeDelete(d *schema.ResourceData, meta interface{}) error {
+	err := checkOrganization(meta)
+	if err != nil {
+		return err
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+
+	req, err := client.NewRequest("DELETE", fmt.Sprintf("orgs/%v/issue-types/%v", orgName, d.Id()), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(ctx, req, nil)
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	return err
+}
+
+func expandIssueType(d *schema.ResourceData) *issueType {
+	t := &issueType{
+		Name:      d.Get("name").(string),
+		IsEnabled: d.Get("is_enabled").(bool),
+	}
+	if v, ok := d.GetOk("description"); ok {
+		t.Description = github.String(v.(string))
+	}
+	if v, ok := d.GetOk("color"); ok {
+		t.Color = github.String(v.(string))
+	}
+	return t
+}
+
+func listOrganizationIssueTypes(ctx context.Context, client *github.Client, org string) ([]*issueType, error) {
+	req, err := client.NewRequest("GET", fmt.Sprintf("orgs/%v/issue-types", org), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var issueTypes []*issueType
+	if _, err = client.Do(ctx, req, &issueTypes); err != nil {
+		return nil, err
+	}
+	return issueTypes, nil
+}
@@ -0,0 +1,103 @@
+package github
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccGithubOrganizationIssueType(t *testing.T) {
+
+	randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+
+	t.Run("creates an issue type and uses it on sub-issues", func(t *testing.T) {
+
+		config := fmt.Sprintf(`
+			resource "github_organization_issue_type" "test" {
+				name        = "tf-acc-test-%[1]s"
+				description = "Terraform acceptance tests"
+				color       = "blue"
+			}
+
+			resource "github_repository" "test" {
+				name       = "tf-acc-test-issue-types-%[1]s"
+				has_issues = true
+			}
+
+			resource "github_issue" "parent" {
+				repository = github_repository.test.name
+				title      = "parent"
+				issue_type = github_organization_issue_type.test.name
+			}
+
+			resource "github_issue" "child" {
+				repository          = github_repository.test.name
+				title               = "child"
+				parent_issue_number = github_issue.parent.number
+				state               = "open"
+			}
+
+			data "github_issue_sub_issues" "test" {
+				repository = github_repository.test.name
+				number     = github_issue.parent.number
+
+				depends_on = [github_issue.child]
+			}
+		`, randomID)
+
+		checks := map[string]resource.TestCheckFunc{
+			"before": resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("github_organization_issue_type.test", "color", "blue"),
+				resource.TestCheckResourceAttr("github_organization_issue_type.test", "is_enabled", "true"),
+				resource.TestCheckResourceAttrPair("github_issue.parent", "issue_type", "github_organization_issue_type.test", "name"),
+				resource.TestCheckResourceAttrPair("github_issue.child", "parent_issue_number", "github_issue.parent", "number"),
+				resource.TestCheckResourceAttr("data.github_issue_sub_issues.test", "sub_issues.#", "1"),
+				resource.TestCheckResourceAttr("data.github_issue_sub_issues.test", "sub_issues.0.title", "child"),
+			),
+			"after": resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("github_issue.child", "state", "closed"),
+				resource.TestCheckResourceAttr("github_issue.child", "state_reason", "not_planned"),
+			),
+		}
+
+		testCase := func(t *testing.T, mode string) {
+			resource.Test(t, resource.TestCase{
+				PreCheck:  func() { skipUnlessMode(t, mode) },
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: config,
+						Check:  checks["before"],
+					},
+					{
+						Config: strings.Replace(config,
+							`state               = "open"`,
+							`state               = "closed"
+				state_reason        = "not_planned"`, 1),
+						Check: checks["after"],
+					},
+					{
+						ResourceName:      "github_organization_issue_type.test",
+						ImportState:       true,
+						ImportStateVerify: true,
+					},
+				},
+			})
+		}
+
+		t.Run("with an anonymous account", func(t *testing.T) {
+			t.Skip("anonymous account not supported for this operation")
+		})
+
+		t.Run("with an individual account", func(t *testing.T) {
+			t.Skip("individual account not supported for this operation")
+		})
+
+		t.Run("with an organization account", func(t *testing.T) {
+			testCase(t, organization)
+		})
+	})
+}
@@ -660,6 +660,12 @@ func resourceGithubOrganizationRulesetCreate(d *schema.ResourceData, meta interf
 
 	ctx := context.Background()
 
+	if d.HasChange("rules.0.required_workflows") {
+		if err := checkRulesetRequiredWorkflowsExist(ctx, client, owner, d); err != nil {
+			return err
+		}
+	}
+
 	var ruleset *github.Ruleset
 	var err error
 
@@ -731,6 +737,12 @@ func resourceGithubOrganizationRulesetUpdate(d *schema.ResourceData, meta interf
 
 	ctx := context.WithValue(context.Background(), ctxId, d.Id())
 
+	if d.HasChange("rules.0.required_workflows") {
+		if err := checkRulesetRequiredWorkflowsExist(ctx, client, owner, d); err != nil {
+			return err
+		}
+	}
+
 	ruleset, _, err := client.Organizations.UpdateOrganizationRuleset(ctx, owner, rulesetID, rulesetReq)
 	if err != nil {
 		return err
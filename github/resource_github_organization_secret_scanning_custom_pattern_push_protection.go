@@ -0,0 +1,222 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// The pattern configurations of an organization are not modelled by
+// go-github yet. Custom patterns themselves cannot be created, updated or
+// dry run with the API, only the push protection of the existing ones can be
+// set. Patterns are identified by their token type, as display names are not
+// unique. There is no equivalent endpoint for the patterns of a repository.
+
+type secretScanningPatternConfigurations struct {
+	PatternConfigVersion   string                           `json:"pattern_config_version,omitempty"`
+	CustomPatternOverrides []*secretScanningPatternOverride `json:"custom_pattern_overrides,omitempty"`
+}
+
+type secretScanningPatternOverride struct {
+	TokenType            string `json:"token_type"`
+	CustomPatternVersion string `json:"custom_pattern_version"`
+	DisplayName          string `json:"display_name"`
+	Setting              string `json:"setting"`
+}
+
+type secretScanningPatternConfigurationsUpdate struct {
+	PatternConfigVersion  string                                `json:"pattern_config_version"`
+	CustomPatternSettings []*secretScanningCustomPatternSetting `json:"custom_pattern_settings"`
+}
+
+type secretScanningCustomPatternSetting struct {
+	TokenType             string `json:"token_type"`
+	CustomPatternVersion  string `json:"custom_pattern_version"`
+	PushProtectionSetting string `json:"push_protection_setting"`
+}
+
+func resourceGithubOrganizationSecretScanningCustomPatternPushProtection() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGithubOrganizationSecretScanningCustomPatternPushProtectionCreateOrUpdate,
+		Read:   resourceGithubOrganizationSecretScanningCustomPatternPushProtectionRead,
+		Update: resourceGithubOrganizationSecretScanningCustomPatternPushProtectionCreateOrUpdate,
+		Delete: resourceGithubOrganizationSecretScanningCustomPatternPushProtectionDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"token_type": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The token type GitHub identifies the custom secret scanning pattern of the organization with.",
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Required:    true,
+				Description: "Whether pushes containing secrets matching the pattern are blocked.",
+			},
+			"pattern_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the pattern.",
+			},
+			"custom_pattern_version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The version of the pattern, which changes whenever it is edited.",
+			},
+		},
+	}
+}
+
+func resourceGithubOrganizationSecretScanningCustomPatternPushProtectionCreateOrUpdate(d *schema.ResourceData, meta interface{}) error {
+	err := checkOrganization(meta)
+	if err != nil {
+		return err
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+	tokenType := d.Get("token_type").(string)
+	ctx := context.Background()
+	if !d.IsNewResource() {
+		ctx = context.WithValue(ctx, ctxId, d.Id())
+	}
+
+	setting := "disabled"
+	if d.Get("enabled").(bool) {
+		setting = "enabled"
+	}
+	if err = setSecretScanningCustomPatternPushProtection(ctx, client, orgName, tokenType, setting); err != nil {
+		return err
+	}
+
+	d.SetId(tokenType)
+	return resourceGithubOrganizationSecretScanningCustomPatternPushProtectionRead(d, meta)
+}
+
+func resourceGithubOrganizationSecretScanningCustomPatternPushProtectionRead(d *schema.ResourceData, meta interface{}) error {
+	err := checkOrganization(meta)
+	if err != nil {
+		return err
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+
+	configurations, err := getSecretScanningPatternConfigurations(ctx, client, orgName)
+	if err != nil {
+		return err
+	}
+
+	pattern := findSecretScanningCustomPattern(configurations, d.Id())
+	if pattern == nil {
+		log.Printf("[INFO] Removing secret scanning custom pattern push protection %s/%s from state because the pattern no longer exists in GitHub",
+			orgName, d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err = d.Set("token_type", pattern.TokenType); err != nil {
+		return err
+	}
+	if err = d.Set("enabled", pattern.Setting == "enabled"); err != nil {
+		return err
+	}
+	if err = d.Set("pattern_name", pattern.DisplayName); err != nil {
+		return err
+	}
+	if err = d.Set("custom_pattern_version", pattern.CustomPatternVersion); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceGithubOrganizationSecretScanningCustomPatternPushProtectionDelete(d *schema.ResourceData, meta interface{}) error {
+	err := checkOrganization(meta)
+	if err != nil {
+		return err
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+
+	err = setSecretScanningCustomPatternPushProtection(ctx, client, orgName, d.Id(), "disabled")
+	if _, ok := err.(*secretScanningCustomPatternNotFoundError); ok {
+		return nil
+	}
+	return err
+}
+
+type secretScanningCustomPatternNotFoundError struct {
+	org, tokenType string
+}
+
+func (e *secretScanningCustomPatternNotFoundError) Error() string {
+	return fmt.Sprintf("custom secret scanning pattern with token type %q not found in organization %s", e.tokenType, e.org)
+}
+
+// setSecretScanningCustomPatternPushProtection sets the push protection of a
+// pattern. The current version of the configurations and of the pattern are
+// read first, as GitHub rejects updates made against outdated ones.
+func setSecretScanningCustomPatternPushProtection(ctx context.Context, client *github.Client, org, tokenType, setting string) error {
+	configurations, err := getSecretScanningPatternConfigurations(ctx, client, org)
+	if err != nil {
+		return err
+	}
+
+	pattern := findSecretScanningCustomPattern(configurations, tokenType)
+	if pattern == nil {
+		return &secretScanningCustomPatternNotFoundError{org: org, tokenType: tokenType}
+	}
+	if pattern.Setting == setting {
+		return nil
+	}
+
+	update := &secretScanningPatternConfigurationsUpdate{
+		PatternConfigVersion: configurations.PatternConfigVersion,
+		CustomPatternSettings: []*secretScanningCustomPatternSetting{
+			{
+				TokenType:             pattern.TokenType,
+				CustomPatternVersion:  pattern.CustomPatternVersion,
+				PushProtectionSetting: setting,
+			},
+		},
+	}
+	req, err := client.NewRequest("PATCH", fmt.Sprintf("orgs/%v/secret-scanning/pattern-configurations", org), update)
+	if err != nil {
+		return err
+	}
+	_, err = client.Do(ctx, req, nil)
+	return err
+}
+
+func getSecretScanningPatternConfigurations(ctx context.Context, client *github.Client, org string) (*secretScanningPatternConfigurations, error) {
+	req, err := client.NewRequest("GET", fmt.Sprintf("orgs/%v/secret-scanning/pattern-configurations", org), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	configurations := new(secretScanningPatternConfigurations)
+	if _, err = client.Do(ctx, req, configurations); err != nil {
+		return nil, err
+	}
+	return configurations, nil
+}
+
+func findSecretScanningCustomPattern(configurations *secretScanningPatternConfigurations, tokenType string) *secretScanningPatternOverride {
+	for _, pattern := range configurations.CustomPatternOverrides {
+		if pattern.TokenType == tokenType {
+			return pattern
+		}
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package github
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestResourceGithubOrganizationSecretScanningCustomPatternPushProtectionCreate(t *testing.T) {
+	configurations := `{
+		"pattern_config_version": "v1",
+		"custom_pattern_overrides": [
+			{"token_type": "cp_2", "custom_pattern_version": "p2", "display_name": "Internal token", "setting": "enabled"},
+			{"token_type": "cp_1", "custom_pattern_version": "p1", "display_name": "Internal token", "setting": "not-set"}
+		]
+	}`
+
+	ts := githubApiMock([]*mockResponse{
+		{
+			ExpectedUri:    "/orgs/octo/secret-scanning/pattern-configurations",
+			ExpectedMethod: "GET",
+			ResponseBody:   configurations,
+			StatusCode:     200,
+		},
+		{
+			ExpectedUri:    "/orgs/octo/secret-scanning/pattern-configurations",
+			ExpectedMethod: "PATCH",
+			ExpectedBody: []byte(`{"pattern_config_version":"v1","custom_pattern_settings":[` +
+				`{"token_type":"cp_1","custom_pattern_version":"p1","push_protection_setting":"enabled"}]}` + "\n"),
+			ResponseBody: `{"pattern_config_version": "v2"}`,
+			StatusCode:   200,
+		},
+		{
+			ExpectedUri:    "/orgs/octo/secret-scanning/pattern-configurations",
+			ExpectedMethod: "GET",
+			ResponseBody: `{
+				"pattern_config_version": "v2",
+				"custom_pattern_overrides": [
+					{"token_type": "cp_1", "custom_pattern_version": "p1", "display_name": "Internal token", "setting": "enabled"}
+				]
+			}`,
+			StatusCode: 200,
+		},
+	})
+	defer ts.Close()
+
+	client := github.NewClient(nil)
+	client.BaseURL, _ = url.Parse(ts.URL + "/")
+	meta := &Owner{name: "octo", v3client: client, IsOrganization: true}
+
+	d := schema.TestResourceDataRaw(t, resourceGithubOrganizationSecretScanningCustomPatternPushProtection().Schema, map[string]interface{}{
+		"token_type": "cp_1",
+		"enabled":    true,
+	})
+
+	if err := resourceGithubOrganizationSecretScanningCustomPatternPushProtectionCreateOrUpdate(d, meta); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if d.Id() != "cp_1" {
+		t.Errorf("Expected the ID to be the token type, got %q", d.Id())
+	}
+	if name := d.Get("pattern_name").(string); name != "Internal token" {
+		t.Errorf("Expected the pattern name to be Internal token, got %q", name)
+	}
+	if !d.Get("enabled").(bool) {
+		t.Error("Expected push protection to be enabled")
+	}
+}
+
+func TestResourceGithubOrganizationSecretScanningCustomPatternPushProtectionDelete(t *testing.T) {
+	ts := githubApiMock([]*mockResponse{
+		{
+			ExpectedUri:    "/orgs/octo/secret-scanning/pattern-configurations",
+			ExpectedMethod: "GET",
+			ResponseBody:   `{"pattern_config_version": "v1", "custom_pattern_overrides": []}`,
+			StatusCode:     200,
+		},
+	})
+	defer ts.Close()
+
+	client := github.NewClient(nil)
+	client.BaseURL, _ = url.Parse(ts.URL + "/")
+	meta := &Owner{name: "octo", v3client: client, IsOrganization: true}
+
+	d := schema.TestResourceDataRaw(t, resourceGithubOrganizationSecretScanningCustomPatternPushProtection().Schema, map[string]interface{}{
+		"token_type": "cp_1",
+		"enabled":    true,
+	})
+	d.SetId("cp_1")
+
+	if err := resourceGithubOrganizationSecretScanningCustomPatternPushProtectionDelete(d, meta); err != nil {
+		t.Fatalf("Expected a deleted pattern to be ignored, got %s", err)
+	}
+}
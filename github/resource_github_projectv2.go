@@ -0,0 +1,242 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/shurcooL/githubv4"
+)
+
+func resourceGithubProjectV2() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGithubProjectV2Create,
+		Read:   resourceGithubProjectV2Read,
+		Update: resourceGithubProjectV2Update,
+		Delete: resourceGithubProjectV2Delete,
+		Importer: &schema.ResourceImporter{
+			State: resourceGithubProjectV2Import,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"owner": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The login of the organization or user owning the project. Defaults to the provider owner.",
+			},
+			"title": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The title of the project.",
+			},
+			"short_description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The short description of the project.",
+			},
+			"readme": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The readme of the project, in Markdown.",
+			},
+			"public": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the project is visible to anyone.",
+			},
+			"closed": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the project is closed.",
+			},
+			"number": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of the project, as found in its URL.",
+			},
+			"url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URL of the project.",
+			},
+		},
+	}
+}
+
+func resourceGithubProjectV2Create(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Owner).v4client
+	ctx := context.Background()
+
+	owner := d.Get("owner").(string)
+	if owner == "" {
+		owner = meta.(*Owner).name
+	}
+
+	ownerID, err := getProjectV2OwnerID(ctx, client, owner)
+	if err != nil {
+		return err
+	}
+
+	var mutate struct {
+		CreateProjectV2 struct {
+			ProjectV2 struct {
+				ID githubv4.ID
+			}
+		} `graphql:"createProjectV2(input:$input)"`
+	}
+	input := githubv4.CreateProjectV2Input{
+		OwnerID: ownerID,
+		Title:   githubv4.String(d.Get("title").(string)),
+	}
+
+	if err = client.Mutate(ctx, &mutate, input, nil); err != nil {
+		return err
+	}
+	d.SetId(fmt.Sprintf("%s", mutate.CreateProjectV2.ProjectV2.ID))
+
+	// Only the title can be set on creation.
+	if err = updateProjectV2(ctx, d, client); err != nil {
+		return err
+	}
+
+	return resourceGithubProjectV2Read(d, meta)
+}
+
+func resourceGithubProjectV2Read(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Owner).v4client
+	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+
+	var query struct {
+		Node struct {
+			ProjectV2 struct {
+				Title            githubv4.String
+				ShortDescription githubv4.String
+				Readme           githubv4.String
+				Public           githubv4.Boolean
+				Closed           githubv4.Boolean
+				Number           githubv4.Int
+				URL              githubv4.URI `graphql:"url"`
+				Owner            struct {
+					Organization struct {
+						Login githubv4.String
+					} `graphql:"... on Organization"`
+					User struct {
+						Login githubv4.String
+					} `graphql:"... on User"`
+				}
+			} `graphql:"... on ProjectV2"`
+		} `graphql:"node(id:$id)"`
+	}
+	variables := map[string]interface{}{
+		"id": githubv4.ID(d.Id()),
+	}
+
+	if err := client.Query(ctx, &query, variables); err != nil {
+		if projectV2NotFound(err) {
+			log.Printf("[INFO] Removing project %s from state because it no longer exists in GitHub", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	project := query.Node.ProjectV2
+	owner := project.Owner.Organization.Login
+	if owner == "" {
+		owner = project.Owner.User.Login
+	}
+
+	if err := d.Set("owner", owner); err != nil {
+		return err
+	}
+	if err := d.Set("title", project.Title); err != nil {
+		return err
+	}
+	if err := d.Set("short_description", project.ShortDescription); err != nil {
+		return err
+	}
+	if err := d.Set("readme", project.Readme); err != nil {
+		return err
+	}
+	if err := d.Set("public", project.Public); err != nil {
+		return err
+	}
+	if err := d.Set("closed", project.Closed); err != nil {
+		return err
+	}
+	if err := d.Set("number", project.Number); err != nil {
+		return err
+	}
+	if err := d.Set("url", project.URL.String()); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceGithubProjectV2Update(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Owner).v4client
+	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+
+	if err := updateProjectV2(ctx, d, client); err != nil {
+		return err
+	}
+
+	return resourceGithubProjectV2Read(d, meta)
+}
+
+func resourceGithubProjectV2Delete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Owner).v4client
+	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+
+	var mutate struct {
+		DeleteProjectV2 struct {
+			ProjectV2 struct {
+				ID githubv4.ID
+			}
+		} `graphql:"deleteProjectV2(input:$input)"`
+	}
+	input := githubv4.DeleteProjectV2Input{
+		ProjectID: githubv4.ID(d.Id()),
+	}
+
+	return client.Mutate(ctx, &mutate, input, nil)
+}
+
+func resourceGithubProjectV2Import(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*Owner).v4client
+	ctx := context.Background()
+
+	projectID, err := parseProjectV2ImportID(ctx, client, d.Id())
+	if err != nil {
+		return nil, err
+	}
+	d.SetId(projectID)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func updateProjectV2(ctx context.Context, d *schema.ResourceData, client *githubv4.Client) error {
+	var mutate struct {
+		UpdateProjectV2 struct {
+			ProjectV2 struct {
+				ID githubv4.ID
+			}
+		} `graphql:"updateProjectV2(input:$input)"`
+	}
+	input := githubv4.UpdateProjectV2Input{
+		ProjectID:        githubv4.ID(d.Id()),
+		Title:            githubv4.NewString(githubv4.String(d.Get("title").(string))),
+		ShortDescription: githubv4.NewString(githubv4.String(d.Get("short_description").(string))),
+		Readme:           githubv4.NewString(githubv4.String(d.Get("readme").(string))),
+		Public:           githubv4.NewBoolean(githubv4.Boolean(d.Get("public").(bool))),
+		Closed:           githubv4.NewBoolean(githubv4.Boolean(d.Get("closed").(bool))),
+	}
+
+	return client.Mutate(ctx, &mutate, input, nil)
+}
@@ -0,0 +1,331 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/shurcooL/githubv4"
+)
+
+var projectV2DateRegexp = regexp.MustCompile(`^[0-9]{4}-[0-9]{2}-[0-9]{2}$`)
+
+func resourceGithubProjectV2Field() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGithubProjectV2FieldCreate,
+		Read:   resourceGithubProjectV2FieldRead,
+		Update: resourceGithubProjectV2FieldUpdate,
+		Delete: resourceGithubProjectV2FieldDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The node ID of the project.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the field.",
+			},
+			"data_type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateDiagFunc: validateValueFunc([]string{
+					string(githubv4.ProjectV2CustomFieldTypeText),
+					string(githubv4.ProjectV2CustomFieldTypeNumber),
+					string(githubv4.ProjectV2CustomFieldTypeDate),
+					string(githubv4.ProjectV2CustomFieldTypeSingleSelect),
+					string(githubv4.ProjectV2CustomFieldTypeIteration),
+				}),
+				Description: "The data type of the field. Must be one of 'TEXT', 'NUMBER', 'DATE', 'SINGLE_SELECT' or 'ITERATION'.",
+			},
+			"option": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The options of a 'SINGLE_SELECT' field, in order. Changing the options recreates all of them, which clears the value of the field on every item.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The name of the option.",
+						},
+						"color": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  string(githubv4.ProjectV2SingleSelectFieldOptionColorGray),
+							ValidateDiagFunc: validateValueFunc([]string{
+								"GRAY", "BLUE", "GREEN", "YELLOW", "ORANGE", "RED", "PINK", "PURPLE",
+							}),
+							Description: "The color of the option. Must be one of 'GRAY', 'BLUE', 'GREEN', 'YELLOW', 'ORANGE', 'RED', 'PINK' or 'PURPLE'.",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The description of the option.",
+						},
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the option.",
+						},
+					},
+				},
+			},
+			"iteration_configuration": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "The configuration of an 'ITERATION' field. Changing it recreates the iterations, which clears the value of the field on every item.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"start_date": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: toDiagFunc(validation.StringMatch(projectV2DateRegexp, "must be a date in 'YYYY-MM-DD' format"), "start_date"),
+							Description:      "The start date of the first iteration, in 'YYYY-MM-DD' format.",
+						},
+						"duration": {
+							Type:             schema.TypeInt,
+							Required:         true,
+							ValidateDiagFunc: toDiagFunc(validation.IntAtLeast(1), "duration"),
+							Description:      "The duration of each iteration, in days.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceGithubProjectV2FieldCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Owner).v4client
+	ctx := context.Background()
+
+	dataType := githubv4.ProjectV2CustomFieldType(d.Get("data_type").(string))
+	options, iterationConfiguration, err := expandProjectV2FieldConfiguration(d, dataType)
+	if err != nil {
+		return err
+	}
+
+	var mutate struct {
+		CreateProjectV2Field struct {
+			ProjectV2Field projectV2FieldConfiguration
+		} `graphql:"createProjectV2Field(input:$input)"`
+	}
+	input := githubv4.CreateProjectV2FieldInput{
+		ProjectID:              githubv4.ID(d.Get("project_id").(string)),
+		DataType:               dataType,
+		Name:                   githubv4.String(d.Get("name").(string)),
+		SingleSelectOptions:    options,
+		IterationConfiguration: iterationConfiguration,
+	}
+
+	if err = client.Mutate(ctx, &mutate, input, nil); err != nil {
+		return err
+	}
+	d.SetId(fmt.Sprintf("%s", mutate.CreateProjectV2Field.ProjectV2Field.Common.ID))
+
+	return resourceGithubProjectV2FieldRead(d, meta)
+}
+
+func resourceGithubProjectV2FieldRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Owner).v4client
+	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+
+	var query struct {
+		Node projectV2FieldConfiguration `graphql:"node(id:$id)"`
+	}
+	variables := map[string]interface{}{
+		"id": githubv4.ID(d.Id()),
+	}
+
+	if err := client.Query(ctx, &query, variables); err != nil {
+		if projectV2NotFound(err) {
+			log.Printf("[INFO] Removing project field %s from state because it no longer exists in GitHub", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	field := query.Node
+	if err := d.Set("project_id", fmt.Sprintf("%s", field.Common.Project.ID)); err != nil {
+		return err
+	}
+	if err := d.Set("name", field.Common.Name); err != nil {
+		return err
+	}
+	if err := d.Set("data_type", field.Common.DataType); err != nil {
+		return err
+	}
+
+	options := make([]interface{}, 0, len(field.SingleSelectField.Options))
+	for _, option := range field.SingleSelectField.Options {
+		options = append(options, map[string]interface{}{
+			"id":          option.ID,
+			"name":        option.Name,
+			"color":       option.Color,
+			"description": option.Description,
+		})
+	}
+	if err := d.Set("option", options); err != nil {
+		return err
+	}
+
+	iterationConfiguration := []interface{}{}
+	if githubv4.ProjectV2CustomFieldType(field.Common.DataType) == githubv4.ProjectV2CustomFieldTypeIteration {
+		iterationConfiguration = append(iterationConfiguration, map[string]interface{}{
+			"start_date": field.IterationField.Configuration.StartDate.Format(time.DateOnly),
+			"duration":   field.IterationField.Configuration.Duration,
+		})
+	}
+	if err := d.Set("iteration_configuration", iterationConfiguration); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceGithubProjectV2FieldUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Owner).v4client
+	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+
+	dataType := githubv4.ProjectV2CustomFieldType(d.Get("data_type").(string))
+	options, iterationConfiguration, err := expandProjectV2FieldConfiguration(d, dataType)
+	if err != nil {
+		return err
+	}
+
+	// The options and iterations sent replace the existing ones, which loses
+	// the values of the items for the field, so they are only sent when
+	// they changed.
+	if !d.HasChange("option") {
+		options = nil
+	}
+	if !d.HasChange("iteration_configuration") {
+		iterationConfiguration = nil
+	}
+
+	var mutate struct {
+		UpdateProjectV2Field struct {
+			ProjectV2Field projectV2FieldConfiguration
+		} `graphql:"updateProjectV2Field(input:$input)"`
+	}
+	input := githubv4.UpdateProjectV2FieldInput{
+		FieldID:                githubv4.ID(d.Id()),
+		Name:                   githubv4.NewString(githubv4.String(d.Get("name").(string))),
+		SingleSelectOptions:    options,
+		IterationConfiguration: iterationConfiguration,
+	}
+
+	if err = client.Mutate(ctx, &mutate, input, nil); err != nil {
+		return err
+	}
+
+	return resourceGithubProjectV2FieldRead(d, meta)
+}
+
+func resourceGithubProjectV2FieldDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Owner).v4client
+	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+
+	var mutate struct {
+		DeleteProjectV2Field struct {
+			ProjectV2Field projectV2FieldConfiguration
+		} `graphql:"deleteProjectV2Field(input:$input)"`
+	}
+	input := githubv4.DeleteProjectV2FieldInput{
+		FieldID: githubv4.ID(d.Id()),
+	}
+
+	return client.Mutate(ctx, &mutate, input, nil)
+}
+
+// projectV2FieldConfiguration queries the ProjectV2FieldConfiguration union.
+// The options and the iteration configuration are only filled in for fields
+// of the matching type.
+type projectV2FieldConfiguration struct {
+	Common struct {
+		ID       githubv4.ID
+		Name     githubv4.String
+		DataType githubv4.String
+		Project  struct {
+			ID githubv4.ID
+		}
+	} `graphql:"... on ProjectV2FieldCommon"`
+	SingleSelectField struct {
+		Options []struct {
+			ID          githubv4.String
+			Name        githubv4.String
+			Color       githubv4.String
+			Description githubv4.String
+		}
+	} `graphql:"... on ProjectV2SingleSelectField"`
+	IterationField struct {
+		Configuration struct {
+			StartDate githubv4.Date
+			Duration  githubv4.Int
+		}
+	} `graphql:"... on ProjectV2IterationField"`
+}
+
+// expandProjectV2FieldConfiguration returns the options or the iteration
+// configuration of a field, whichever its data type supports.
+func expandProjectV2FieldConfiguration(d *schema.ResourceData, dataType githubv4.ProjectV2CustomFieldType) (*[]githubv4.ProjectV2SingleSelectFieldOptionInput, *githubv4.ProjectV2IterationFieldConfigurationInput, error) {
+	optionsConfig := d.Get("option").([]interface{})
+	iterationConfig := d.Get("iteration_configuration").([]interface{})
+
+	if len(optionsConfig) > 0 && dataType != githubv4.ProjectV2CustomFieldTypeSingleSelect {
+		return nil, nil, fmt.Errorf("option can only be set on fields of data_type SINGLE_SELECT")
+	}
+	if len(iterationConfig) > 0 && dataType != githubv4.ProjectV2CustomFieldTypeIteration {
+		return nil, nil, fmt.Errorf("iteration_configuration can only be set on fields of data_type ITERATION")
+	}
+
+	switch dataType {
+	case githubv4.ProjectV2CustomFieldTypeSingleSelect:
+		if len(optionsConfig) == 0 {
+			return nil, nil, fmt.Errorf("at least one option is required on fields of data_type SINGLE_SELECT")
+		}
+
+		options := make([]githubv4.ProjectV2SingleSelectFieldOptionInput, 0, len(optionsConfig))
+		for _, o := range optionsConfig {
+			option := o.(map[string]interface{})
+			options = append(options, githubv4.ProjectV2SingleSelectFieldOptionInput{
+				Name:        githubv4.String(option["name"].(string)),
+				Color:       githubv4.ProjectV2SingleSelectFieldOptionColor(option["color"].(string)),
+				Description: githubv4.String(option["description"].(string)),
+			})
+		}
+		return &options, nil, nil
+
+	case githubv4.ProjectV2CustomFieldTypeIteration:
+		if len(iterationConfig) == 0 {
+			return nil, nil, fmt.Errorf("iteration_configuration is required on fields of data_type ITERATION")
+		}
+
+		config := iterationConfig[0].(map[string]interface{})
+		startDate, err := time.Parse(time.DateOnly, config["start_date"].(string))
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, &githubv4.ProjectV2IterationFieldConfigurationInput{
+			StartDate:  githubv4.Date{Time: startDate},
+			Duration:   githubv4.Int(config["duration"].(int)),
+			Iterations: []githubv4.ProjectV2Iteration{},
+		}, nil
+	}
+
+	return nil, nil, nil
+}
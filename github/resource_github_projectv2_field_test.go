@@ -0,0 +1,167 @@
+package github
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/shurcooL/githubv4"
+)
+
+func TestExpandProjectV2FieldConfiguration(t *testing.T) {
+	cases := []struct {
+		name        string
+		raw         map[string]interface{}
+		options     int
+		iteration   bool
+		expectError bool
+	}{
+		{
+			name: "text",
+			raw:  map[string]interface{}{"data_type": "TEXT"},
+		},
+		{
+			name: "single select",
+			raw: map[string]interface{}{
+				"data_type": "SINGLE_SELECT",
+				"option": []interface{}{
+					map[string]interface{}{"name": "High", "color": "RED"},
+					map[string]interface{}{"name": "Low"},
+				},
+			},
+			options: 2,
+		},
+		{
+			name:        "single select without options",
+			raw:         map[string]interface{}{"data_type": "SINGLE_SELECT"},
+			expectError: true,
+		},
+		{
+			name: "iteration",
+			raw: map[string]interface{}{
+				"data_type": "ITERATION",
+				"iteration_configuration": []interface{}{
+					map[string]interface{}{"start_date": "2025-01-06", "duration": 14},
+				},
+			},
+			iteration: true,
+		},
+		{
+			name: "options on a text field",
+			raw: map[string]interface{}{
+				"data_type": "TEXT",
+				"option": []interface{}{
+					map[string]interface{}{"name": "High"},
+				},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceGithubProjectV2Field().Schema, tc.raw)
+			dataType := githubv4.ProjectV2CustomFieldType(tc.raw["data_type"].(string))
+
+			options, iteration, err := expandProjectV2FieldConfiguration(d, dataType)
+			if tc.expectError {
+				if err == nil {
+					t.Fatal("Expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			if tc.options == 0 && options != nil {
+				t.Errorf("Expected no options, got %v", *options)
+			}
+			if tc.options > 0 {
+				if options == nil || len(*options) != tc.options {
+					t.Fatalf("Expected %d options, got %v", tc.options, options)
+				}
+				if color := (*options)[1].Color; color != githubv4.ProjectV2SingleSelectFieldOptionColorGray {
+					t.Errorf("Expected the default color GRAY, got %s", color)
+				}
+			}
+			if tc.iteration != (iteration != nil) {
+				t.Errorf("Expected iteration configuration %t, got %v", tc.iteration, iteration)
+			}
+			if iteration != nil && (iteration.Duration != 14 || iteration.StartDate.Format("2006-01-02") != "2025-01-06") {
+				t.Errorf("Unexpected iteration configuration %v", iteration)
+			}
+		})
+	}
+}
+
+func TestResourceGithubProjectV2FieldUpdateRename(t *testing.T) {
+	node := `{"id": "PVTSSF_1", "name": "State", "dataType": "SINGLE_SELECT", "project": {"id": "PVT_1"},
+		"options": [{"id": "a1", "name": "Todo", "color": "GRAY", "description": ""}]}`
+
+	var mutations []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		if strings.Contains(string(body), "mutation") {
+			mutations = append(mutations, string(body))
+			mustWrite(w, `{"data": {"updateProjectV2Field": {"projectV2Field": `+node+`}}}`)
+			return
+		}
+		mustWrite(w, `{"data": {"node": `+node+`}}`)
+	}))
+	defer ts.Close()
+
+	meta := &Owner{v4client: githubv4.NewEnterpriseClient(ts.URL, ts.Client())}
+
+	r := resourceGithubProjectV2Field()
+	state := &terraform.InstanceState{
+		ID: "PVTSSF_1",
+		Attributes: map[string]string{
+			"id":                   "PVTSSF_1",
+			"project_id":           "PVT_1",
+			"name":                 "Status",
+			"data_type":            "SINGLE_SELECT",
+			"option.#":             "1",
+			"option.0.id":          "a1",
+			"option.0.name":        "Todo",
+			"option.0.color":       "GRAY",
+			"option.0.description": "",
+		},
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"project_id": "PVT_1",
+		"name":       "State",
+		"data_type":  "SINGLE_SELECT",
+		"option": []interface{}{
+			map[string]interface{}{"name": "Todo", "color": "GRAY"},
+		},
+	})
+	diff, err := schema.InternalMap(r.Schema).Diff(context.Background(), state, config, nil, nil, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := schema.InternalMap(r.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := resourceGithubProjectV2FieldUpdate(d, meta); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if len(mutations) != 1 {
+		t.Fatalf("Expected 1 mutation, got %d", len(mutations))
+	}
+	if !strings.Contains(mutations[0], `"name":"State"`) {
+		t.Errorf("Expected the field to be renamed, got %s", mutations[0])
+	}
+	if strings.Contains(mutations[0], "singleSelectOptions") || strings.Contains(mutations[0], "iterationConfiguration") {
+		t.Errorf("Expected the options and iterations not to be sent on a rename, got %s", mutations[0])
+	}
+}
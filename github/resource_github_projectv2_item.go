@@ -0,0 +1,276 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/shurcooL/githubv4"
+)
+
+func resourceGithubProjectV2Item() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGithubProjectV2ItemCreate,
+		Read:   resourceGithubProjectV2ItemRead,
+		Update: resourceGithubProjectV2ItemUpdate,
+		Delete: resourceGithubProjectV2ItemDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The node ID of the project.",
+			},
+			"content_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"content_id", "draft_title"},
+				Description:  "The node ID of the issue or pull request to add to the project.",
+			},
+			"draft_title": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"content_id", "draft_title"},
+				Description:  "The title of a draft issue to add to the project.",
+			},
+			"draft_body": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"content_id"},
+				Description:   "The body of the draft issue.",
+			},
+			"archived": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the item is archived.",
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The type of the item: 'ISSUE', 'PULL_REQUEST' or 'DRAFT_ISSUE'.",
+			},
+			"draft_issue_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The node ID of the draft issue, for draft items.",
+			},
+		},
+	}
+}
+
+func resourceGithubProjectV2ItemCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Owner).v4client
+	ctx := context.Background()
+
+	projectID := githubv4.ID(d.Get("project_id").(string))
+
+	if contentID, ok := d.GetOk("content_id"); ok {
+		var mutate struct {
+			AddProjectV2ItemById struct {
+				Item struct {
+					ID githubv4.ID
+				}
+			} `graphql:"addProjectV2ItemById(input:$input)"`
+		}
+		input := githubv4.AddProjectV2ItemByIdInput{
+			ProjectID: projectID,
+			ContentID: githubv4.ID(contentID.(string)),
+		}
+
+		if err := client.Mutate(ctx, &mutate, input, nil); err != nil {
+			return err
+		}
+		d.SetId(fmt.Sprintf("%s", mutate.AddProjectV2ItemById.Item.ID))
+	} else {
+		var mutate struct {
+			AddProjectV2DraftIssue struct {
+				ProjectItem struct {
+					ID githubv4.ID
+				}
+			} `graphql:"addProjectV2DraftIssue(input:$input)"`
+		}
+		input := githubv4.AddProjectV2DraftIssueInput{
+			ProjectID: projectID,
+			Title:     githubv4.String(d.Get("draft_title").(string)),
+			Body:      githubv4.NewString(githubv4.String(d.Get("draft_body").(string))),
+		}
+
+		if err := client.Mutate(ctx, &mutate, input, nil); err != nil {
+			return err
+		}
+		d.SetId(fmt.Sprintf("%s", mutate.AddProjectV2DraftIssue.ProjectItem.ID))
+	}
+
+	if d.Get("archived").(bool) {
+		if err := setProjectV2ItemArchived(ctx, client, projectID, githubv4.ID(d.Id()), true); err != nil {
+			return err
+		}
+	}
+
+	return resourceGithubProjectV2ItemRead(d, meta)
+}
+
+func resourceGithubProjectV2ItemRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Owner).v4client
+	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+
+	var query struct {
+		Node struct {
+			ProjectV2Item struct {
+				Type       githubv4.String
+				IsArchived githubv4.Boolean
+				Project    struct {
+					ID githubv4.ID
+				}
+				Content struct {
+					DraftIssue struct {
+						ID    githubv4.ID
+						Title githubv4.String
+						Body  githubv4.String
+					} `graphql:"... on DraftIssue"`
+					Issue struct {
+						ID githubv4.ID
+					} `graphql:"... on Issue"`
+					PullRequest struct {
+						ID githubv4.ID
+					} `graphql:"... on PullRequest"`
+				}
+			} `graphql:"... on ProjectV2Item"`
+		} `graphql:"node(id:$id)"`
+	}
+	variables := map[string]interface{}{
+		"id": githubv4.ID(d.Id()),
+	}
+
+	if err := client.Query(ctx, &query, variables); err != nil {
+		if projectV2NotFound(err) {
+			log.Printf("[INFO] Removing project item %s from state because it no longer exists in GitHub", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	item := query.Node.ProjectV2Item
+	if err := d.Set("project_id", fmt.Sprintf("%s", item.Project.ID)); err != nil {
+		return err
+	}
+	if err := d.Set("type", item.Type); err != nil {
+		return err
+	}
+	if err := d.Set("archived", item.IsArchived); err != nil {
+		return err
+	}
+
+	// Every fragment of the content union is filled in with its ID, so the
+	// item type tells them apart.
+	switch githubv4.ProjectV2ItemType(item.Type) {
+	case githubv4.ProjectV2ItemTypeDraftIssue:
+		if err := d.Set("draft_issue_id", fmt.Sprintf("%s", item.Content.DraftIssue.ID)); err != nil {
+			return err
+		}
+		if err := d.Set("draft_title", item.Content.DraftIssue.Title); err != nil {
+			return err
+		}
+		if err := d.Set("draft_body", item.Content.DraftIssue.Body); err != nil {
+			return err
+		}
+	case githubv4.ProjectV2ItemTypeIssue:
+		if err := d.Set("content_id", fmt.Sprintf("%s", item.Content.Issue.ID)); err != nil {
+			return err
+		}
+	case githubv4.ProjectV2ItemTypePullRequest:
+		if err := d.Set("content_id", fmt.Sprintf("%s", item.Content.PullRequest.ID)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func resourceGithubProjectV2ItemUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Owner).v4client
+	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+
+	if d.HasChanges("draft_title", "draft_body") {
+		var mutate struct {
+			UpdateProjectV2DraftIssue struct {
+				DraftIssue struct {
+					ID githubv4.ID
+				}
+			} `graphql:"updateProjectV2DraftIssue(input:$input)"`
+		}
+		input := githubv4.UpdateProjectV2DraftIssueInput{
+			DraftIssueID: githubv4.ID(d.Get("draft_issue_id").(string)),
+			Title:        githubv4.NewString(githubv4.String(d.Get("draft_title").(string))),
+			Body:         githubv4.NewString(githubv4.String(d.Get("draft_body").(string))),
+		}
+
+		if err := client.Mutate(ctx, &mutate, input, nil); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("archived") {
+		projectID := githubv4.ID(d.Get("project_id").(string))
+		if err := setProjectV2ItemArchived(ctx, client, projectID, githubv4.ID(d.Id()), d.Get("archived").(bool)); err != nil {
+			return err
+		}
+	}
+
+	return resourceGithubProjectV2ItemRead(d, meta)
+}
+
+func resourceGithubProjectV2ItemDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Owner).v4client
+	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+
+	var mutate struct {
+		DeleteProjectV2Item struct {
+			DeletedItemID githubv4.ID `graphql:"deletedItemId"`
+		} `graphql:"deleteProjectV2Item(input:$input)"`
+	}
+	input := githubv4.DeleteProjectV2ItemInput{
+		ProjectID: githubv4.ID(d.Get("project_id").(string)),
+		ItemID:    githubv4.ID(d.Id()),
+	}
+
+	return client.Mutate(ctx, &mutate, input, nil)
+}
+
+func setProjectV2ItemArchived(ctx context.Context, client *githubv4.Client, projectID githubv4.ID, itemID githubv4.ID, archived bool) error {
+	if archived {
+		var mutate struct {
+			ArchiveProjectV2Item struct {
+				Item struct {
+					ID githubv4.ID
+				}
+			} `graphql:"archiveProjectV2Item(input:$input)"`
+		}
+		input := githubv4.ArchiveProjectV2ItemInput{
+			ProjectID: projectID,
+			ItemID:    itemID,
+		}
+		return client.Mutate(ctx, &mutate, input, nil)
+	}
+
+	var mutate struct {
+		UnarchiveProjectV2Item struct {
+			Item struct {
+				ID githubv4.ID
+			}
+		} `graphql:"unarchiveProjectV2Item(input:$input)"`
+	}
+	input := githubv4.UnarchiveProjectV2ItemInput{
+		ProjectID: projectID,
+		ItemID:    itemID,
+	}
+	return client.Mutate(ctx, &mutate, input, nil)
+}
@@ -0,0 +1,107 @@
+package github
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccGithubProjectV2(t *testing.T) {
+
+	randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+
+	t.Run("manages a project with fields and items without error", func(t *testing.T) {
+
+		config := fmt.Sprintf(`
+			resource "github_projectv2" "test" {
+				title             = "tf-acc-test-%[1]s"
+				short_description = "Test project"
+			}
+
+			resource "github_projectv2_field" "status" {
+				project_id = github_projectv2.test.id
+				name       = "Priority"
+				data_type  = "SINGLE_SELECT"
+
+				option {
+					name  = "High"
+					color = "RED"
+				}
+
+				option {
+					name = "Low"
+				}
+			}
+
+			resource "github_projectv2_field" "sprint" {
+				project_id = github_projectv2.test.id
+				name       = "Sprint"
+				data_type  = "ITERATION"
+
+				iteration_configuration {
+					start_date = "2025-01-06"
+					duration   = 14
+				}
+			}
+
+			resource "github_projectv2_item" "draft" {
+				project_id  = github_projectv2.test.id
+				draft_title = "Draft item"
+				draft_body  = "Created by an acceptance test"
+			}
+		`, randomID)
+
+		check := resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttr(
+				"github_projectv2.test", "title", fmt.Sprintf("tf-acc-test-%s", randomID),
+			),
+			resource.TestCheckResourceAttrSet(
+				"github_projectv2.test", "number",
+			),
+			resource.TestCheckResourceAttr(
+				"github_projectv2_field.status", "option.#", "2",
+			),
+			resource.TestCheckResourceAttr(
+				"github_projectv2_field.status", "option.1.color", "GRAY",
+			),
+			resource.TestCheckResourceAttr(
+				"github_projectv2_field.sprint", "iteration_configuration.0.duration", "14",
+			),
+			resource.TestCheckResourceAttr(
+				"github_projectv2_item.draft", "type", "DRAFT_ISSUE",
+			),
+		)
+
+		testCase := func(t *testing.T, mode string) {
+			resource.Test(t, resource.TestCase{
+				PreCheck:  func() { skipUnlessMode(t, mode) },
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: config,
+						Check:  check,
+					},
+					{
+						ResourceName:      "github_projectv2.test",
+						ImportState:       true,
+						ImportStateVerify: true,
+					},
+				},
+			})
+		}
+
+		t.Run("with an anonymous account", func(t *testing.T) {
+			t.Skip("anonymous account not supported for this operation")
+		})
+
+		t.Run("with an individual account", func(t *testing.T) {
+			testCase(t, individual)
+		})
+
+		t.Run("with an organization account", func(t *testing.T) {
+			testCase(t, organization)
+		})
+	})
+}
@@ -0,0 +1,263 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// codeScanningDefaultSetupPollInterval is the interval at which the run
+// configuring default setup is polled. It is a variable for the tests.
+var codeScanningDefaultSetupPollInterval = 10 * time.Second
+
+// codeScanningDefaultSetup is the default setup configuration of a repository.
+// go-github does not model the runner and threat model settings.
+type codeScanningDefaultSetup struct {
+	State       string     `json:"state,omitempty"`
+	Languages   []string   `json:"languages,omitempty"`
+	QuerySuite  string     `json:"query_suite,omitempty"`
+	RunnerType  string     `json:"runner_type,omitempty"`
+	RunnerLabel string     `json:"runner_label,omitempty"`
+	ThreatModel string     `json:"threat_model,omitempty"`
+	Schedule    string     `json:"schedule,omitempty"`
+	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
+}
+
+func resourceGithubRepositoryCodeScanningDefaultSetup() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGithubRepositoryCodeScanningDefaultSetupCreateOrUpdate,
+		Read:   resourceGithubRepositoryCodeScanningDefaultSetupRead,
+		Update: resourceGithubRepositoryCodeScanningDefaultSetupCreateOrUpdate,
+		Delete: resourceGithubRepositoryCodeScanningDefaultSetupDelete,
+		Importer: &schema.ResourceImporter{
+			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				if err := d.Set("repository", d.Id()); err != nil {
+					return nil, err
+				}
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"repository": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the repository.",
+			},
+			"state": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "configured",
+				ValidateDiagFunc: validateValueFunc([]string{"configured", "not-configured"}),
+				Description:      "Whether default setup is configured. Must be one of 'configured' or 'not-configured'.",
+			},
+			"languages": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validateValueFunc([]string{"actions", "c-cpp", "csharp", "go", "java-kotlin", "javascript-typescript", "python", "ruby", "swift"}),
+				},
+				Description: "The languages to analyze. Defaults to the languages detected in the repository.",
+			},
+			"query_suite": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ValidateDiagFunc: validateValueFunc([]string{"default", "extended"}),
+				Description:      "The CodeQL query suite to use. Must be one of 'default' or 'extended'.",
+			},
+			"runner_type": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ValidateDiagFunc: validateValueFunc([]string{"standard", "labeled"}),
+				Description:      "The type of runner to use. Must be one of 'standard' or 'labeled'.",
+			},
+			"runner_label": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The label of the runners to use when 'runner_type' is 'labeled'.",
+			},
+			"threat_model": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ValidateDiagFunc: validateValueFunc([]string{"remote", "remote_and_local"}),
+				Description:      "The threat model to use. Must be one of 'remote' or 'remote_and_local'.",
+			},
+			"schedule": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The frequency of the periodic analysis.",
+			},
+			"updated_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time default setup was last updated.",
+			},
+		},
+	}
+}
+
+func resourceGithubRepositoryCodeScanningDefaultSetupCreateOrUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Owner).v3client
+	ctx := context.Background()
+
+	owner := meta.(*Owner).name
+	repoName := d.Get("repository").(string)
+
+	setup := &codeScanningDefaultSetup{
+		State:       d.Get("state").(string),
+		Languages:   expandStringList(d.Get("languages").(*schema.Set).List()),
+		QuerySuite:  d.Get("query_suite").(string),
+		RunnerType:  d.Get("runner_type").(string),
+		ThreatModel: d.Get("threat_model").(string),
+	}
+	if setup.RunnerType == "labeled" {
+		setup.RunnerLabel = d.Get("runner_label").(string)
+	}
+
+	timeout := d.Timeout(schema.TimeoutCreate)
+	if !d.IsNewResource() {
+		timeout = d.Timeout(schema.TimeoutUpdate)
+	}
+	if err := updateCodeScanningDefaultSetup(ctx, client, owner, repoName, setup, timeout); err != nil {
+		return err
+	}
+
+	d.SetId(repoName)
+	return resourceGithubRepositoryCodeScanningDefaultSetupRead(d, meta)
+}
+
+func resourceGithubRepositoryCodeScanningDefaultSetupRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Owner).v3client
+	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+
+	owner := meta.(*Owner).name
+	repoName := d.Id()
+
+	req, err := client.NewRequest("GET", fmt.Sprintf("repos/%v/%v/code-scanning/default-setup", owner, repoName), nil)
+	if err != nil {
+		return err
+	}
+
+	setup := new(codeScanningDefaultSetup)
+	if _, err = client.Do(ctx, req, setup); err != nil {
+		if ghErr, ok := err.(*github.ErrorResponse); ok {
+			if ghErr.Response.StatusCode == http.StatusNotFound {
+				log.Printf("[INFO] Removing code scanning default setup of repository %s/%s from state because it no longer exists in GitHub",
+					owner, repoName)
+				d.SetId("")
+				return nil
+			}
+		}
+		return err
+	}
+
+	updatedAt := ""
+	if setup.UpdatedAt != nil {
+		updatedAt = setup.UpdatedAt.Format(time.RFC3339)
+	}
+
+	values := map[string]interface{}{
+		"repository":   repoName,
+		"state":        setup.State,
+		"languages":    flattenStringList(setup.Languages),
+		"query_suite":  setup.QuerySuite,
+		"runner_type":  setup.RunnerType,
+		"runner_label": setup.RunnerLabel,
+		"threat_model": setup.ThreatModel,
+		"schedule":     setup.Schedule,
+		"updated_at":   updatedAt,
+	}
+	for k, v := range values {
+		if err = d.Set(k, v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func resourceGithubRepositoryCodeScanningDefaultSetupDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Owner).v3client
+	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+
+	owner := meta.(*Owner).name
+	repoName := d.Get("repository").(string)
+
+	setup := &codeScanningDefaultSetup{State: "not-configured"}
+	return updateCodeScanningDefaultSetup(ctx, client, owner, repoName, setup, d.Timeout(schema.TimeoutDelete))
+}
+
+// updateCodeScanningDefaultSetup updates the default setup of a repository
+// and, as the update is applied by a workflow run, waits for the run to
+// complete so that failures are reported.
+func updateCodeScanningDefaultSetup(ctx context.Context, client *github.Client, owner, repo string, setup *codeScanningDefaultSetup, timeout time.Duration) error {
+	req, err := client.NewRequest("PATCH", fmt.Sprintf("repos/%v/%v/code-scanning/default-setup", owner, repo), setup)
+	if err != nil {
+		return err
+	}
+
+	run := new(github.UpdateDefaultSetupConfigurationResponse)
+	if _, err = client.Do(ctx, req, run); err != nil {
+		// The update is accepted with a 202 when a run is started, which
+		// go-github reports as an error without decoding the body.
+		aerr, ok := err.(*github.AcceptedError)
+		if !ok {
+			return err
+		}
+		if err = json.Unmarshal(aerr.Raw, run); err != nil {
+			return err
+		}
+	}
+	if run.GetRunID() == 0 {
+		return nil
+	}
+
+	return waitForCodeScanningDefaultSetupRun(ctx, client, owner, repo, run.GetRunID(), timeout)
+}
+
+func waitForCodeScanningDefaultSetupRun(ctx context.Context, client *github.Client, owner, repo string, runID int64, timeout time.Duration) error {
+	conf := &retry.StateChangeConf{
+		Pending:      []string{"requested", "queued", "pending", "waiting", "in_progress"},
+		Target:       []string{"completed"},
+		Timeout:      timeout,
+		PollInterval: codeScanningDefaultSetupPollInterval,
+		Refresh: func() (interface{}, string, error) {
+			run, _, err := client.Actions.GetWorkflowRunByID(ctx, owner, repo, runID)
+			if err != nil {
+				return nil, "", err
+			}
+			return run, run.GetStatus(), nil
+		},
+	}
+
+	result, err := conf.WaitForStateContext(ctx)
+	if err != nil {
+		return fmt.Errorf("error waiting for code scanning default setup run %d: %s", runID, err)
+	}
+
+	run := result.(*github.WorkflowRun)
+	if conclusion := run.GetConclusion(); conclusion != "success" {
+		return fmt.Errorf("code scanning default setup run %d concluded with %q, see %s", runID, conclusion, run.GetHTMLURL())
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccGithubRepositoryCodeScanningDefaultSetup(t *testing.T) {
+
+	randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+
+	t.Run("configures default setup without error", func(t *testing.T) {
+
+		config := fmt.Sprintf(`
+			resource "github_repository" "test" {
+				name       = "tf-acc-test-code-scanning-%s"
+				visibility = "public"
+				auto_init  = true
+			}
+
+			resource "github_repository_file" "test" {
+				repository = github_repository.test.name
+				file       = "main.py"
+				content    = "print('hello')"
+			}
+
+			resource "github_repository_code_scanning_default_setup" "test" {
+				repository  = github_repository.test.name
+				languages   = ["python"]
+				query_suite = "default"
+
+				depends_on = [github_repository_file.test]
+			}
+		`, randomID)
+
+		checks := map[string]resource.TestCheckFunc{
+			"before": resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr(
+					"github_repository_code_scanning_default_setup.test", "state", "configured",
+				),
+				resource.TestCheckResourceAttr(
+					"github_repository_code_scanning_default_setup.test", "query_suite", "default",
+				),
+			),
+			"after": resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr(
+					"github_repository_code_scanning_default_setup.test", "query_suite", "extended",
+				),
+			),
+		}
+
+		testCase := func(t *testing.T, mode string) {
+			resource.Test(t, resource.TestCase{
+				PreCheck:  func() { skipUnlessMode(t, mode) },
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: config,
+						Check:  checks["before"],
+					},
+					{
+						Config: strings.Replace(config,
+							`query_suite = "default"`,
+							`query_suite = "extended"`, 1),
+						Check: checks["after"],
+					},
+					{
+						ResourceName:      "github_repository_code_scanning_default_setup.test",
+						ImportState:       true,
+						ImportStateVerify: true,
+					},
+				},
+			})
+		}
+
+		t.Run("with an anonymous account", func(t *testing.T) {
+			t.Skip("anonymous account not supported for this operation")
+		})
+
+		t.Run("with an individual account", func(t *testing.T) {
+			testCase(t, individual)
+		})
+
+		t.Run("with an organization account", func(t *testing.T) {
+			testCase(t, organization)
+		})
+	})
+}
+
+func TestUpdateCodeScanningDefaultSetup(t *testing.T) {
+	codeScanningDefaultSetupPollInterval = time.Millisecond
+	defer func() { codeScanningDefaultSetupPollInterval = 10 * time.Second }()
+
+	ts := githubApiMock([]*mockResponse{
+		{
+			ExpectedUri:    "/repos/octo/hello/code-scanning/default-setup",
+			ExpectedMethod: "PATCH",
+			ExpectedBody:   []byte(`{"state":"configured","languages":["python"]}` + "\n"),
+			ResponseBody:   `{"run_id": 42, "run_url": "https://api.github.com/repos/octo/hello/actions/runs/42"}`,
+			StatusCode:     202,
+		},
+		{
+			ExpectedUri:    "/repos/octo/hello/actions/runs/42",
+			ExpectedMethod: "GET",
+			ResponseBody:   `{"id": 42, "status": "in_progress"}`,
+			StatusCode:     200,
+		},
+		{
+			ExpectedUri:    "/repos/octo/hello/actions/runs/42",
+			ExpectedMethod: "GET",
+			ResponseBody:   `{"id": 42, "status": "completed", "conclusion": "failure", "html_url": "https://github.com/octo/hello/actions/runs/42"}`,
+			StatusCode:     200,
+		},
+	})
+	defer ts.Close()
+
+	client := github.NewClient(nil)
+	client.BaseURL, _ = url.Parse(ts.URL + "/")
+
+	setup := &codeScanningDefaultSetup{State: "configured", Languages: []string{"python"}}
+	err := updateCodeScanningDefaultSetup(context.Background(), client, "octo", "hello", setup, time.Minute)
+	if err == nil {
+		t.Fatal("Expected an error, got none")
+	}
+	if !strings.Contains(err.Error(), `concluded with "failure"`) {
+		t.Errorf("Unexpected error: %s", err)
+	}
+}
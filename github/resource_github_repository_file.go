@@ -144,6 +144,7 @@ func resourceGithubRepositoryFile() *schema.Resource {
 				RequiredWith:     []string{"autocreate_branch"},
 				DiffSuppressFunc: autoBranchDiffSuppressFunc,
 			},
+			"pull_request": pullRequestModeSchema(),
 		},
 	}
 }
@@ -239,6 +240,18 @@ func resourceGithubRepositoryFileCreate(d *schema.ResourceData, meta interface{}
 		opts.Message = &m
 	}
 
+	var base, head string
+	if pullRequestModeConfig(d) != nil {
+		if base, err = getPullRequestModeBaseBranch(ctx, d, client, owner, repo); err != nil {
+			return err
+		}
+		if head, err = preparePullRequestModeBranch(ctx, d, client, owner, repo, base); err != nil {
+			return err
+		}
+		opts.Branch = github.String(head)
+		checkOpt.Ref = head
+	}
+
 	log.Printf("[DEBUG] Checking if overwriting a repository file: %s/%s/%s", owner, repo, file)
 	fileContent, _, resp, err := client.Repositories.GetContents(ctx, owner, repo, file, &checkOpt)
 	if err != nil {
@@ -275,6 +288,12 @@ func resourceGithubRepositoryFileCreate(d *schema.ResourceData, meta interface{}
 		return err
 	}
 
+	if head != "" {
+		if err = syncPullRequestModePullRequest(ctx, d, meta, owner, repo, base, head, *opts.Message); err != nil {
+			return err
+		}
+	}
+
 	return resourceGithubRepositoryFileRead(d, meta)
 }
 
@@ -303,6 +322,16 @@ func resourceGithubRepositoryFileRead(d *schema.ResourceData, meta interface{})
 		opts.Ref = branch.(string)
 	}
 
+	// In pull request mode the file is read from the head branch while the
+	// pull request is open.
+	head, err := readPullRequestModeBranch(ctx, d, client, owner, repo)
+	if err != nil {
+		return err
+	}
+	if head != "" {
+		opts.Ref = head
+	}
+
 	fc, _, _, err := client.Repositories.GetContents(ctx, owner, repo, file, opts)
 	if err != nil {
 		var errorResponse *github.ErrorResponse
@@ -437,6 +466,17 @@ func resourceGithubRepositoryFileUpdate(d *schema.ResourceData, meta interface{}
 		opts.Message = &m
 	}
 
+	var base, head string
+	if pullRequestModeConfig(d) != nil {
+		if base, err = getPullRequestModeBaseBranch(ctx, d, client, owner, repo); err != nil {
+			return err
+		}
+		if head, err = preparePullRequestModeBranch(ctx, d, client, owner, repo, base); err != nil {
+			return err
+		}
+		opts.Branch = github.String(head)
+	}
+
 	create, _, err := client.Repositories.CreateFile(ctx, owner, repo, file, opts)
 	if err != nil {
 		return err
@@ -446,6 +486,12 @@ func resourceGithubRepositoryFileUpdate(d *schema.ResourceData, meta interface{}
 		return err
 	}
 
+	if head != "" {
+		if err = syncPullRequestModePullRequest(ctx, d, meta, owner, repo, base, head, *opts.Message); err != nil {
+			return err
+		}
+	}
+
 	return resourceGithubRepositoryFileRead(d, meta)
 }
 
@@ -503,7 +549,24 @@ func resourceGithubRepositoryFileDelete(d *schema.ResourceData, meta interface{}
 		opts.Branch = &branch
 	}
 
+	// In pull request mode the deletion only lands on the head branch, and the
+	// file stays on the base branch until the pull request is merged.
+	var base, head string
+	if pullRequestModeConfig(d) != nil {
+		var err error
+		if base, err = getPullRequestModeBaseBranch(ctx, d, client, owner, repo); err != nil {
+			return err
+		}
+		if head, err = preparePullRequestModeBranch(ctx, d, client, owner, repo, base); err != nil {
+			return err
+		}
+		opts.Branch = &head
+	}
+
 	_, _, err := client.Repositories.DeleteFile(ctx, owner, repo, file, opts)
+	if err == nil && head != "" {
+		err = syncPullRequestModePullRequest(ctx, d, meta, owner, repo, base, head, message)
+	}
 	return handleArchivedRepoDelete(err, "repository file", file, owner, repo)
 }
 
@@ -0,0 +1,453 @@
+package github
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceGithubRepositoryFiles() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGithubRepositoryFilesCreate,
+		Read:   resourceGithubRepositoryFilesRead,
+		Update: resourceGithubRepositoryFilesUpdate,
+		Delete: resourceGithubRepositoryFilesDelete,
+
+		Schema: map[string]*schema.Schema{
+			"repository": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The repository name",
+			},
+			"branch": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The branch name, defaults to the repository's default branch",
+			},
+			"files": {
+				Type:        schema.TypeMap,
+				Required:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "A map of file paths to their content. Files removed from the map are deleted from the branch.",
+			},
+			"delete_files": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "File paths to delete from the branch in the same commit, if they exist",
+			},
+			"commit_message": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The commit message when creating, updating or deleting the files",
+			},
+			"commit_author": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"commit_email"},
+				Description:  "The commit author name, defaults to the authenticated user's name. GitHub app users may omit author and email information so GitHub can verify commits as the GitHub App.",
+			},
+			"commit_email": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"commit_author"},
+				Description:  "The commit author email address, defaults to the authenticated user's email address. GitHub app users may omit author and email information so GitHub can verify commits as the GitHub App.",
+			},
+			"commit_sha": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The SHA of the last commit that modified the files",
+			},
+			"file_shas": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "A map of file paths to their blob SHA",
+			},
+			"overwrite_on_create": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Enable overwriting existing files, defaults to \"false\"",
+				Default:     false,
+			},
+			"autocreate_branch": {
+				Type:             schema.TypeBool,
+				Optional:         true,
+				Description:      "Automatically create the branch if it could not be found. Subsequent reads if the branch is deleted will occur from 'autocreate_branch_source_branch'",
+				Default:          false,
+				DiffSuppressFunc: autoBranchDiffSuppressFunc,
+			},
+			"autocreate_branch_source_branch": {
+				Type:             schema.TypeString,
+				Default:          "main",
+				Optional:         true,
+				Description:      "The branch name to start from, if 'autocreate_branch' is set. Defaults to 'main'.",
+				RequiredWith:     []string{"autocreate_branch"},
+				DiffSuppressFunc: autoBranchDiffSuppressFunc,
+			},
+			"autocreate_branch_source_sha": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				Description:      "The commit hash to start from, if 'autocreate_branch' is set. Defaults to the tip of 'autocreate_branch_source_branch'. If provided, 'autocreate_branch_source_branch' is ignored.",
+				RequiredWith:     []string{"autocreate_branch"},
+				DiffSuppressFunc: autoBranchDiffSuppressFunc,
+			},
+			"pull_request": pullRequestModeSchema(),
+		},
+	}
+}
+
+func resourceGithubRepositoryFilesCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Owner).v3client
+	owner := meta.(*Owner).name
+	ctx := context.Background()
+
+	repo := d.Get("repository").(string)
+
+	branch, ok := d.GetOk("branch")
+	if !ok {
+		repository, _, err := client.Repositories.Get(ctx, owner, repo)
+		if err != nil {
+			return err
+		}
+		branch = repository.GetDefaultBranch()
+		if err = d.Set("branch", branch); err != nil {
+			return err
+		}
+	}
+
+	if err := createRepositoryBranchIfMissing(ctx, d, client, owner, repo, branch.(string)); err != nil {
+		return err
+	}
+
+	files := expandRepositoryFiles(d.Get("files").(map[string]interface{}))
+	deletions := expandStringList(d.Get("delete_files").(*schema.Set).List())
+
+	if err := commitRepositoryFilesToBranch(ctx, d, meta, owner, repo, branch.(string), files, deletions, "Add files", d.Get("overwrite_on_create").(bool)); err != nil {
+		return err
+	}
+
+	d.SetId(buildTwoPartID(repo, branch.(string)))
+
+	return resourceGithubRepositoryFilesRead(d, meta)
+}
+
+func resourceGithubRepositoryFilesRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Owner).v3client
+	owner := meta.(*Owner).name
+	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+
+	repo := d.Get("repository").(string)
+	branch := d.Get("branch").(string)
+
+	if err := checkRepositoryBranchExists(client, owner, repo, branch); err != nil {
+		if d.Get("autocreate_branch").(bool) {
+			branch = d.Get("autocreate_branch_source_branch").(string)
+		} else {
+			log.Printf("[INFO] Removing repository files %s/%s from state because the branch no longer exists in GitHub",
+				repo, branch)
+			d.SetId("")
+			return nil
+		}
+	}
+
+	// In pull request mode the files are read from the head branch while the
+	// pull request is open.
+	head, err := readPullRequestModeBranch(ctx, d, client, owner, repo)
+	if err != nil {
+		return err
+	}
+	if head != "" {
+		branch = head
+	}
+
+	ref, _, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/"+branch)
+	if err != nil {
+		return err
+	}
+	existing, err := getRepositoryTreeBlobs(ctx, client, owner, repo, ref.Object.GetSHA())
+	if err != nil {
+		return err
+	}
+
+	// Only the content of files whose blob differs from the known content is
+	// downloaded. Files missing from the branch are dropped so that the next
+	// plan adds them back.
+	files := map[string]interface{}{}
+	fileSHAs := map[string]interface{}{}
+	for path, c := range d.Get("files").(map[string]interface{}) {
+		sha, ok := existing[path]
+		if !ok {
+			log.Printf("[INFO] Repository file %s/%s/%s no longer exists in GitHub", repo, branch, path)
+			continue
+		}
+
+		content := c.(string)
+		if sha != gitBlobSHA(content) {
+			log.Printf("[INFO] Repository file %s/%s/%s was modified outside of Terraform", repo, branch, path)
+			raw, _, err := client.Git.GetBlobRaw(ctx, owner, repo, sha)
+			if err != nil {
+				return err
+			}
+			content = string(raw)
+		}
+
+		files[path] = content
+		fileSHAs[path] = sha
+	}
+
+	// Files that should be deleted but exist again are dropped for the same
+	// reason.
+	deletions := []interface{}{}
+	for _, path := range d.Get("delete_files").(*schema.Set).List() {
+		if _, ok := existing[path.(string)]; !ok {
+			deletions = append(deletions, path)
+		}
+	}
+
+	if err = d.Set("files", files); err != nil {
+		return err
+	}
+	if err = d.Set("file_shas", fileSHAs); err != nil {
+		return err
+	}
+	if err = d.Set("delete_files", deletions); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceGithubRepositoryFilesUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Owner).v3client
+	owner := meta.(*Owner).name
+	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+
+	repo := d.Get("repository").(string)
+	branch := d.Get("branch").(string)
+
+	if !d.HasChanges("files", "delete_files") {
+		return resourceGithubRepositoryFilesRead(d, meta)
+	}
+
+	if err := createRepositoryBranchIfMissing(ctx, d, client, owner, repo, branch); err != nil {
+		return err
+	}
+
+	files := expandRepositoryFiles(d.Get("files").(map[string]interface{}))
+	deletions := expandStringList(d.Get("delete_files").(*schema.Set).List())
+
+	o, _ := d.GetChange("files")
+	for path := range o.(map[string]interface{}) {
+		if _, ok := files[path]; !ok {
+			deletions = append(deletions, path)
+		}
+	}
+
+	if err := commitRepositoryFilesToBranch(ctx, d, meta, owner, repo, branch, files, deletions, "Update files", true); err != nil {
+		return err
+	}
+
+	return resourceGithubRepositoryFilesRead(d, meta)
+}
+
+func resourceGithubRepositoryFilesDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Owner).v3client
+	owner := meta.(*Owner).name
+	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+
+	repo := d.Get("repository").(string)
+	branch := d.Get("branch").(string)
+
+	if err := checkRepositoryBranchExists(client, owner, repo, branch); err != nil {
+		log.Printf("[INFO] Not deleting repository files %s/%s because the branch no longer exists in GitHub", repo, branch)
+		return nil
+	}
+
+	deletions := []string{}
+	for path := range d.Get("files").(map[string]interface{}) {
+		deletions = append(deletions, path)
+	}
+
+	// In pull request mode the deletion only lands on the head branch, and the
+	// files stay on the base branch until the pull request is merged.
+	err := commitRepositoryFilesToBranch(ctx, d, meta, owner, repo, branch, nil, deletions, "Delete files", true)
+	return handleArchivedRepoDelete(err, "repository files", branch, owner, repo)
+}
+
+// commitRepositoryFilesToBranch commits the files to the branch, or in pull
+// request mode to the head branch of a pull request against it.
+func commitRepositoryFilesToBranch(ctx context.Context, d *schema.ResourceData, meta interface{}, owner, repo, branch string, files map[string]string, deletions []string, defaultMessage string, overwrite bool) error {
+	client := meta.(*Owner).v3client
+
+	if pullRequestModeConfig(d) == nil {
+		return commitRepositoryFiles(ctx, d, client, owner, repo, branch, files, deletions, defaultMessage, overwrite)
+	}
+
+	head, err := preparePullRequestModeBranch(ctx, d, client, owner, repo, branch)
+	if err != nil {
+		return err
+	}
+	if err = commitRepositoryFiles(ctx, d, client, owner, repo, head, files, deletions, defaultMessage, overwrite); err != nil {
+		return err
+	}
+	return syncPullRequestModePullRequest(ctx, d, meta, owner, repo, branch, head, defaultMessage)
+}
+
+// commitRepositoryFiles writes and deletes files on a branch in a single
+// commit built with the Git Data API. No commit is made when the branch
+// already matches.
+func commitRepositoryFiles(ctx context.Context, d *schema.ResourceData, client *github.Client, owner, repo, branch string, files map[string]string, deletions []string, defaultMessage string, overwrite bool) error {
+	ref, _, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/"+branch)
+	if err != nil {
+		return err
+	}
+	parent, _, err := client.Git.GetCommit(ctx, owner, repo, ref.Object.GetSHA())
+	if err != nil {
+		return err
+	}
+	existing, err := getRepositoryTreeBlobs(ctx, client, owner, repo, parent.Tree.GetSHA())
+	if err != nil {
+		return err
+	}
+
+	entries, err := buildRepositoryFilesTreeEntries(files, deletions, existing, overwrite)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		log.Printf("[DEBUG] Repository files %s/%s/%s are up to date", owner, repo, branch)
+		return d.Set("commit_sha", parent.GetSHA())
+	}
+
+	tree, _, err := client.Git.CreateTree(ctx, owner, repo, parent.Tree.GetSHA(), entries)
+	if err != nil {
+		return err
+	}
+
+	message := defaultMessage
+	if commitMessage, ok := d.GetOk("commit_message"); ok {
+		message = commitMessage.(string)
+	}
+	commit := &github.Commit{
+		Message: github.String(message),
+		Tree:    &github.Tree{SHA: tree.SHA},
+		Parents: []*github.Commit{{SHA: parent.SHA}},
+	}
+	if commitAuthor, ok := d.GetOk("commit_author"); ok {
+		author := &github.CommitAuthor{
+			Name:  github.String(commitAuthor.(string)),
+			Email: github.String(d.Get("commit_email").(string)),
+		}
+		commit.Author = author
+		commit.Committer = author
+	}
+
+	created, _, err := client.Git.CreateCommit(ctx, owner, repo, commit, nil)
+	if err != nil {
+		return err
+	}
+
+	// The reference is not forced so that commits pushed in the meantime are
+	// not lost.
+	ref.Object.SHA = created.SHA
+	if _, _, err = client.Git.UpdateRef(ctx, owner, repo, ref, false); err != nil {
+		return err
+	}
+
+	return d.Set("commit_sha", created.GetSHA())
+}
+
+// getRepositoryTreeBlobs returns the blob SHAs of all the files of a tree,
+// keyed by path.
+func getRepositoryTreeBlobs(ctx context.Context, client *github.Client, owner, repo, sha string) (map[string]string, error) {
+	tree, _, err := client.Git.GetTree(ctx, owner, repo, sha, true)
+	if err != nil {
+		return nil, err
+	}
+	if tree.GetTruncated() {
+		return nil, fmt.Errorf("the tree of %s/%s at %s is too large to be listed", owner, repo, sha)
+	}
+
+	blobs := make(map[string]string, len(tree.Entries))
+	for _, entry := range tree.Entries {
+		if entry.GetType() == "blob" {
+			blobs[entry.GetPath()] = entry.GetSHA()
+		}
+	}
+	return blobs, nil
+}
+
+// buildRepositoryFilesTreeEntries returns the tree entries writing the files
+// whose content differs from the existing blobs and deleting the existing
+// files listed in deletions.
+func buildRepositoryFilesTreeEntries(files map[string]string, deletions []string, existing map[string]string, overwrite bool) ([]*github.TreeEntry, error) {
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	entries := []*github.TreeEntry{}
+	for _, path := range paths {
+		content := files[path]
+		sha, ok := existing[path]
+		if ok && sha == gitBlobSHA(content) {
+			continue
+		}
+		if ok && !overwrite {
+			return nil, fmt.Errorf("refusing to overwrite existing file %s: configure `overwrite_on_create` to `true` to override", path)
+		}
+
+		entries = append(entries, &github.TreeEntry{
+			Path:    github.String(path),
+			Mode:    github.String("100644"),
+			Type:    github.String("blob"),
+			Content: github.String(content),
+		})
+	}
+
+	sort.Strings(deletions)
+	for _, path := range deletions {
+		if _, ok := files[path]; ok {
+			continue
+		}
+		if _, ok := existing[path]; !ok {
+			continue
+		}
+
+		// A nil SHA and content deletes the path.
+		entries = append(entries, &github.TreeEntry{
+			Path: github.String(path),
+			Mode: github.String("100644"),
+			Type: github.String("blob"),
+		})
+	}
+
+	return entries, nil
+}
+
+func expandRepositoryFiles(files map[string]interface{}) map[string]string {
+	expanded := make(map[string]string, len(files))
+	for path, content := range files {
+		expanded[path] = content.(string)
+	}
+	return expanded
+}
+
+// gitBlobSHA returns the SHA git computes for a blob with the given content.
+func gitBlobSHA(content string) string {
+	h := sha1.New()
+	fmt.Fprintf(h, "blob %d\x00%s", len(content), content)
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,134 @@
+package github
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccGithubRepositoryFiles(t *testing.T) {
+
+	randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+
+	t.Run("creates and manages files in a single commit", func(t *testing.T) {
+
+		config := fmt.Sprintf(`
+			resource "github_repository" "test" {
+				name      = "tf-acc-test-%s"
+				auto_init = true
+			}
+
+			resource "github_repository_files" "test" {
+				repository     = github_repository.test.name
+				commit_message = "Managed by Terraform"
+				commit_author  = "Terraform User"
+				commit_email   = "terraform@example.com"
+
+				files = {
+					"test"         = "bar"
+					"docs/test.md" = "# Test"
+				}
+			}
+		`, randomID)
+
+		check := resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttr(
+				"github_repository_files.test", "branch", "main",
+			),
+			resource.TestCheckResourceAttr(
+				"github_repository_files.test", "files.test", "bar",
+			),
+			resource.TestCheckResourceAttr(
+				"github_repository_files.test", "file_shas.test", "ba0e162e1c47469e3fe4b393a8bf8c569f302116",
+			),
+			resource.TestCheckResourceAttrSet(
+				"github_repository_files.test", "file_shas.docs/test.md",
+			),
+			resource.TestCheckResourceAttrSet(
+				"github_repository_files.test", "commit_sha",
+			),
+		)
+
+		testCase := func(t *testing.T, mode string) {
+			resource.Test(t, resource.TestCase{
+				PreCheck:  func() { skipUnlessMode(t, mode) },
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: config,
+						Check:  check,
+					},
+				},
+			})
+		}
+
+		t.Run("with an anonymous account", func(t *testing.T) {
+			t.Skip("anonymous account not supported for this operation")
+		})
+
+		t.Run("with an individual account", func(t *testing.T) {
+			testCase(t, individual)
+		})
+
+		t.Run("with an organization account", func(t *testing.T) {
+			testCase(t, organization)
+		})
+	})
+}
+
+func TestGitBlobSHA(t *testing.T) {
+	if sha := gitBlobSHA("bar"); sha != "ba0e162e1c47469e3fe4b393a8bf8c569f302116" {
+		t.Errorf("Expected ba0e162e1c47469e3fe4b393a8bf8c569f302116, got %s", sha)
+	}
+	if sha := gitBlobSHA(""); sha != "e69de29bb2d1d6434b8b29ae775ad8c2e48c5391" {
+		t.Errorf("Expected e69de29bb2d1d6434b8b29ae775ad8c2e48c5391, got %s", sha)
+	}
+}
+
+func TestBuildRepositoryFilesTreeEntries(t *testing.T) {
+	existing := map[string]string{
+		"unchanged": gitBlobSHA("same"),
+		"changed":   gitBlobSHA("old"),
+		"removed":   gitBlobSHA("gone"),
+	}
+	files := map[string]string{
+		"unchanged": "same",
+		"changed":   "new",
+		"added":     "new",
+	}
+
+	t.Run("writes changed files and deletes existing ones", func(t *testing.T) {
+		entries, err := buildRepositoryFilesTreeEntries(files, []string{"removed", "missing", "added"}, existing, true)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		expected := []struct {
+			path    string
+			deleted bool
+		}{
+			{path: "added"},
+			{path: "changed"},
+			{path: "removed", deleted: true},
+		}
+		if len(entries) != len(expected) {
+			t.Fatalf("Expected %d entries, got %d", len(expected), len(entries))
+		}
+		for i, e := range expected {
+			if entries[i].GetPath() != e.path {
+				t.Errorf("Expected entry %d to be %s, got %s", i, e.path, entries[i].GetPath())
+			}
+			if deleted := entries[i].Content == nil && entries[i].SHA == nil; deleted != e.deleted {
+				t.Errorf("Expected entry %s deleted to be %t, got %t", e.path, e.deleted, deleted)
+			}
+		}
+	})
+
+	t.Run("refuses to overwrite files", func(t *testing.T) {
+		if _, err := buildRepositoryFilesTreeEntries(files, nil, existing, false); err == nil {
+			t.Fatal("Expected an error, got none")
+		}
+	})
+}
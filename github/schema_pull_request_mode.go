@@ -0,0 +1,62 @@
+package github
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func pullRequestModeSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		Optional:    true,
+		Description: "Commit the changes to a separate branch and open a pull request against 'branch' instead of pushing to it directly.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"head_branch": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Computed:    true,
+					Description: "The branch the changes are committed to. Defaults to a generated name. Only generated branches are reset to 'branch', configured ones are fast-forwarded.",
+				},
+				"title": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The title of the pull request. Defaults to the commit message.",
+				},
+				"body": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The body of the pull request.",
+				},
+				"auto_merge": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: "Enable auto-merge on the pull request, or merge it right away if it can be merged.",
+				},
+				"merge_method": {
+					Type:             schema.TypeString,
+					Optional:         true,
+					Default:          "SQUASH",
+					ValidateDiagFunc: validateValueFunc([]string{"MERGE", "SQUASH", "REBASE"}),
+					Description:      "The merge method used by auto-merge. Must be one of 'MERGE', 'SQUASH' or 'REBASE'.",
+				},
+				"number": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "The number of the pull request.",
+				},
+				"state": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The state of the pull request: 'open', 'closed' or 'merged'.",
+				},
+				"url": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The URL of the pull request.",
+				},
+			},
+		},
+	}
+}
@@ -2,9 +2,21 @@ package github
 
 import (
 	"bytes"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
 	"io"
 	"log"
+	"math"
+	"math/rand"
 	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -43,6 +55,232 @@ func NewEtagTransport(rt http.RoundTripper) *etagTransport {
 	return &etagTransport{transport: rt}
 }
 
+// cacheTransport is an HTTP cache for GET requests. Cached responses are
+// always revalidated with If-None-Match or If-Modified-Since, and a 304 Not
+// Modified answer, which does not count against the rate limit, is served
+// from the cache. Responses are keyed by URL, Accept header and an identity
+// of the credentials, and optionally persisted to a directory so they can be
+// reused by later runs. The bodies held in memory are bounded by maxBytes,
+// evicting the least recently used responses first.
+type cacheTransport struct {
+	transport http.RoundTripper
+	identity  string
+	dir       string
+	maxBytes  int64
+
+	m       sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+	size    int64
+}
+
+// defaultCacheMaxBytes bounds the size of the responses cached in memory.
+const defaultCacheMaxBytes = 64 << 20
+
+// cacheItem is an entry of the in-memory cache, in least recently used order.
+type cacheItem struct {
+	key   string
+	entry *cacheEntry
+}
+
+// cacheEntry is a cached response, stored as JSON when persisted.
+type cacheEntry struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"`
+}
+
+type cacheTransportOption func(*cacheTransport)
+
+// NewCacheTransport returns a transport caching responses made with the
+// credentials identified by identity, which must differ between tokens.
+func NewCacheTransport(rt http.RoundTripper, identity string, options ...cacheTransportOption) *cacheTransport {
+	ct := &cacheTransport{
+		transport: rt,
+		identity:  identity,
+		maxBytes:  defaultCacheMaxBytes,
+		entries:   make(map[string]*list.Element),
+		lru:       list.New(),
+	}
+
+	for _, opt := range options {
+		opt(ct)
+	}
+
+	return ct
+}
+
+// WithCacheDir is used to persist cached responses to a directory
+func WithCacheDir(dir string) cacheTransportOption {
+	return func(ct *cacheTransport) {
+		ct.dir = dir
+	}
+}
+
+// WithCacheMaxBytes is used to bound the size of the responses cached in memory
+func WithCacheMaxBytes(n int64) cacheTransportOption {
+	return func(ct *cacheTransport) {
+		ct.maxBytes = n
+	}
+}
+
+func (ct *cacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Requests carrying their own Etag via context are conditional requests
+	// whose 304 response is handled by the caller, so they bypass the cache.
+	if req.Method != http.MethodGet || req.Header.Get("Range") != "" {
+		return ct.transport.RoundTrip(req)
+	}
+	if etag, ok := req.Context().Value(ctxEtag).(string); ok && etag != "" {
+		return ct.transport.RoundTrip(req)
+	}
+
+	key := ct.key(req)
+	entry := ct.load(key)
+	if entry != nil {
+		req = req.Clone(req.Context())
+		if etag := entry.Header.Get("Etag"); etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		if lastModified := entry.Header.Get("Last-Modified"); lastModified != "" {
+			req.Header.Set("If-Modified-Since", lastModified)
+		}
+	}
+
+	resp, err := ct.transport.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && entry != nil {
+		log.Printf("[DEBUG] Serving %s from cache", req.URL)
+		resp.Body.Close()
+
+		// Headers sent along with the 304, e.g. rate limits, replace the cached ones.
+		header := entry.Header.Clone()
+		for name, values := range resp.Header {
+			header[name] = values
+		}
+		entry = &cacheEntry{StatusCode: entry.StatusCode, Header: header, Body: entry.Body}
+		ct.store(key, entry)
+
+		return entry.response(req), nil
+	}
+
+	if resp.StatusCode != http.StatusOK || (resp.Header.Get("Etag") == "" && resp.Header.Get("Last-Modified") == "") {
+		return resp, nil
+	}
+	if strings.Contains(resp.Header.Get("Cache-Control"), "no-store") {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	ct.store(key, &cacheEntry{StatusCode: resp.StatusCode, Header: resp.Header.Clone(), Body: body})
+
+	return resp, nil
+}
+
+// key identifies the cached response for req.
+func (ct *cacheTransport) key(req *http.Request) string {
+	h := sha256.New()
+	for _, part := range []string{ct.identity, req.URL.String(), req.Header.Get("Accept")} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (ct *cacheTransport) load(key string) *cacheEntry {
+	ct.m.Lock()
+	defer ct.m.Unlock()
+
+	if element, ok := ct.entries[key]; ok {
+		ct.lru.MoveToFront(element)
+		return element.Value.(*cacheItem).entry
+	}
+	if ct.dir == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(filepath.Join(ct.dir, key+".json"))
+	if err != nil {
+		return nil
+	}
+	entry := &cacheEntry{}
+	if err := json.Unmarshal(data, entry); err != nil {
+		log.Printf("[DEBUG] Ignoring unreadable cache entry %s: %s", key, err)
+		return nil
+	}
+	ct.remember(key, entry)
+	return entry
+}
+
+func (ct *cacheTransport) store(key string, entry *cacheEntry) {
+	ct.m.Lock()
+	defer ct.m.Unlock()
+
+	ct.remember(key, entry)
+	if ct.dir == "" {
+		return
+	}
+
+	data, err := json.Marshal(entry)
+	if err == nil {
+		err = os.MkdirAll(ct.dir, 0o700)
+	}
+	if err == nil {
+		err = os.WriteFile(filepath.Join(ct.dir, key+".json"), data, 0o600)
+	}
+	if err != nil {
+		log.Printf("[WARN] Unable to persist cache entry to %s: %s", ct.dir, err)
+	}
+}
+
+// remember keeps entry in memory, evicting the least recently used entries
+// beyond maxBytes. Evicted entries persisted to a directory are read again
+// from it. ct.m must be held.
+func (ct *cacheTransport) remember(key string, entry *cacheEntry) {
+	if element, ok := ct.entries[key]; ok {
+		ct.size -= int64(len(element.Value.(*cacheItem).entry.Body))
+		ct.lru.Remove(element)
+		delete(ct.entries, key)
+	}
+	if int64(len(entry.Body)) > ct.maxBytes {
+		return
+	}
+
+	ct.entries[key] = ct.lru.PushFront(&cacheItem{key: key, entry: entry})
+	ct.size += int64(len(entry.Body))
+
+	for ct.size > ct.maxBytes {
+		oldest := ct.lru.Back()
+		item := oldest.Value.(*cacheItem)
+		ct.size -= int64(len(item.entry.Body))
+		ct.lru.Remove(oldest)
+		delete(ct.entries, item.key)
+	}
+}
+
+// response builds a response to req from the cached entry.
+func (e *cacheEntry) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode)),
+		StatusCode:    e.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
+
 // RateLimitTransport implements GitHub's best practices
 // for avoiding rate limits
 // https://developer.github.com/v3/guides/best-practices-for-integrators/#dealing-with-abuse-rate-limits
@@ -52,27 +290,48 @@ type RateLimitTransport struct {
 	writeDelay       time.Duration
 	readDelay        time.Duration
 	parallelRequests bool
+	maxConcurrency   int
 
 	m sync.Mutex
+
+	// scheduler throttles requests when parallelRequests is true.
+	scheduler *requestScheduler
+
+	usage *apiUsage
+
+	// budgets holds the last known rate limit per API resource, e.g. "core"
+	// or "graphql", so requests pause once a budget is exhausted.
+	budgets  map[string]rateLimitBudget
+	budgetsM sync.Mutex
 }
 
-func (rlt *RateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
-	// Make requests for a single user or client ID serially when parallel_requests is false.
-	// If parallel_requests is true skips the lock and allow the parallelism defined by terraform itself.
-	rlt.smartLock(true)
+// rateLimitBudget is the remaining quota of a rate limited API resource.
+type rateLimitBudget struct {
+	remaining int
+	reset     time.Time
+}
 
-	// Sleep for the delay that the last request defined. This delay might be different
-	// for read and write requests. See isWriteMethod for the distinction between them.
-	if rlt.nextRequestDelay > 0 {
-		log.Printf("[DEBUG] Sleeping %s between operations", rlt.nextRequestDelay)
-		time.Sleep(rlt.nextRequestDelay)
+func (rlt *RateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Requests are sent again after a rate limit, so their body must be
+	// readable again.
+	if err := bufferRequestBody(req); err != nil {
+		return nil, err
 	}
 
-	rlt.nextRequestDelay = rlt.calculateNextDelay(req.Method)
+	rlt.acquire(req.Method)
+
+	// Wait for the rate limit to reset when the budget of the API resource
+	// targeted by this request was exhausted by a previous request.
+	resource := rateLimitResource(req)
+	if wait := rlt.budgetWait(resource); wait > 0 {
+		log.Printf("[WARN] Rate limit for %s exhausted, sleeping for %s (until %s) before continuing",
+			resource, wait, time.Now().Add(wait))
+		rlt.sleep(wait)
+	}
 
 	resp, err := rlt.transport.RoundTrip(req)
 	if err != nil {
-		rlt.smartLock(false)
+		rlt.release()
 		return resp, err
 	}
 
@@ -81,38 +340,206 @@ func (rlt *RateLimitTransport) RoundTrip(req *http.Request) (*http.Response, err
 	// See https://github.com/google/go-github/pull/986
 	r1, r2, err := drainBody(resp.Body)
 	if err != nil {
+		rlt.release()
 		return nil, err
 	}
+
+	var graphQLBody []byte
+	if resource == "graphql" {
+		if graphQLBody, err = io.ReadAll(r1); err != nil {
+			rlt.release()
+			return nil, err
+		}
+		r1 = io.NopCloser(bytes.NewReader(graphQLBody))
+	}
+	rlt.updateBudget(resource, resp.Header, graphQLBody)
+
 	resp.Body = r1
 	ghErr := github.CheckResponse(resp)
 	resp.Body = r2
 
+	// GraphQL reports rate limits as errors in the response body, mostly
+	// with a 200 status code which github.CheckResponse doesn't inspect.
+	if ghErr == nil && resource == "graphql" {
+		if retryAfter, secondary, ok := graphQLRateLimit(resp, graphQLBody); ok {
+			if secondary {
+				log.Printf("[WARN] GraphQL secondary rate limit triggered, sleeping for %s before retrying", retryAfter)
+			} else {
+				log.Printf("[WARN] GraphQL rate limit reached, sleeping for %s (until %s) before retrying",
+					retryAfter, time.Now().Add(retryAfter))
+			}
+			if secondary {
+				rlt.throttle()
+			}
+			rlt.sleep(retryAfter)
+			rlt.release()
+			if err := rewindRequestBody(req); err != nil {
+				return nil, err
+			}
+			return rlt.RoundTrip(req)
+		}
+	}
+
 	// When you have been limited, use the Retry-After response header to slow down.
 	if arlErr, ok := ghErr.(*github.AbuseRateLimitError); ok {
-		rlt.nextRequestDelay = 0
 		retryAfter := arlErr.GetRetryAfter()
 		log.Printf("[WARN] Abuse detection mechanism triggered, sleeping for %s before retrying",
 			retryAfter)
-		time.Sleep(retryAfter)
-		rlt.smartLock(false)
+		rlt.throttle()
+		rlt.sleep(retryAfter)
+		rlt.release()
+		if err := rewindRequestBody(req); err != nil {
+			return nil, err
+		}
 		return rlt.RoundTrip(req)
 	}
 
 	if rlErr, ok := ghErr.(*github.RateLimitError); ok {
-		rlt.nextRequestDelay = 0
+		if rlt.scheduler == nil {
+			rlt.nextRequestDelay = 0
+		}
 		retryAfter := time.Until(rlErr.Rate.Reset.Time)
 		log.Printf("[WARN] Rate limit %d reached, sleeping for %s (until %s) before retrying",
 			rlErr.Rate.Limit, retryAfter, time.Now().Add(retryAfter))
-		time.Sleep(retryAfter)
-		rlt.smartLock(false)
+		rlt.sleep(retryAfter)
+		rlt.release()
+		if err := rewindRequestBody(req); err != nil {
+			return nil, err
+		}
 		return rlt.RoundTrip(req)
 	}
 
-	rlt.smartLock(false)
+	if rlt.scheduler != nil {
+		rlt.scheduler.observe(resp.Header)
+	}
+	rlt.release()
 
 	return resp, nil
 }
 
+// bufferRequestBody reads the body of req into memory unless it can already
+// be read again through GetBody.
+func bufferRequestBody(req *http.Request) error {
+	if req.Body == nil || req.Body == http.NoBody || req.GetBody != nil {
+		return nil
+	}
+
+	data, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return err
+	}
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+	req.Body, _ = req.GetBody()
+	return nil
+}
+
+// rewindRequestBody makes the body of req readable again before it is sent
+// again, as sending it consumed it.
+func rewindRequestBody(req *http.Request) error {
+	if req.GetBody == nil {
+		return nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return err
+	}
+	req.Body = body
+	return nil
+}
+
+// sleep waits for a rate limit to reset or be lifted.
+func (rlt *RateLimitTransport) sleep(d time.Duration) {
+	rlt.usage.recordRateLimitWait(d)
+	time.Sleep(d)
+}
+
+// acquire blocks until a request with the given method may be sent.
+// Requests are made serially, for a single user or client ID, when
+// parallel_requests is false. Otherwise the scheduler bounds how many
+// requests are in flight.
+func (rlt *RateLimitTransport) acquire(method string) {
+	if rlt.scheduler != nil {
+		rlt.scheduler.acquire(isWriteMethod(method))
+		return
+	}
+
+	rlt.smartLock(true)
+
+	// Sleep for the delay that the last request defined. This delay might be different
+	// for read and write requests. See isWriteMethod for the distinction between them.
+	if rlt.nextRequestDelay > 0 {
+		log.Printf("[DEBUG] Sleeping %s between operations", rlt.nextRequestDelay)
+		time.Sleep(rlt.nextRequestDelay)
+	}
+
+	rlt.nextRequestDelay = rlt.calculateNextDelay(method)
+}
+
+// release marks the request started by acquire as done.
+func (rlt *RateLimitTransport) release() {
+	if rlt.scheduler != nil {
+		rlt.scheduler.release()
+		return
+	}
+	rlt.smartLock(false)
+}
+
+// throttle reacts to a secondary rate limit by resetting the delay before
+// the next request and reducing concurrency.
+func (rlt *RateLimitTransport) throttle() {
+	if rlt.scheduler != nil {
+		rlt.scheduler.throttle()
+		return
+	}
+	rlt.nextRequestDelay = 0
+}
+
+// budgetWait returns how long to wait before sending a request against the
+// given resource, which is non-zero once its budget is exhausted.
+func (rlt *RateLimitTransport) budgetWait(resource string) time.Duration {
+	rlt.budgetsM.Lock()
+	defer rlt.budgetsM.Unlock()
+
+	budget, ok := rlt.budgets[resource]
+	if !ok || budget.remaining > 0 {
+		return 0
+	}
+
+	wait := time.Until(budget.reset)
+	if wait <= 0 {
+		delete(rlt.budgets, resource)
+		return 0
+	}
+	return wait
+}
+
+// updateBudget records the rate limit reported by a response, from the
+// x-ratelimit-* headers or, for GraphQL, a queried rateLimit field.
+func (rlt *RateLimitTransport) updateBudget(resource string, header http.Header, graphQLBody []byte) {
+	budget, ok := rateLimitFromHeaders(header)
+	if !ok && len(graphQLBody) > 0 {
+		budget, ok = rateLimitFromGraphQL(graphQLBody)
+	}
+	if !ok {
+		return
+	}
+
+	if v := header.Get("x-ratelimit-resource"); v != "" {
+		resource = v
+	}
+
+	rlt.budgetsM.Lock()
+	defer rlt.budgetsM.Unlock()
+
+	if rlt.budgets == nil {
+		rlt.budgets = make(map[string]rateLimitBudget)
+	}
+	rlt.budgets[resource] = budget
+}
+
 // smartLock wraps the mutex locking system and performs its operation via a boolean input for locking and unlocking.
 // It also skips the locking when parallelRequests is set to true since, in this case, the lock is not needed.
 func (rlt *RateLimitTransport) smartLock(lock bool) {
@@ -143,12 +570,16 @@ type RateLimitTransportOption func(*RateLimitTransport)
 func NewRateLimitTransport(rt http.RoundTripper, options ...RateLimitTransportOption) *RateLimitTransport {
 	// Default to 1 second of write delay if none is provided
 	// Default to no read delay if none is provided
-	rlt := &RateLimitTransport{transport: rt, writeDelay: 1 * time.Second, readDelay: 0 * time.Second, parallelRequests: false}
+	rlt := &RateLimitTransport{transport: rt, writeDelay: 1 * time.Second, readDelay: 0 * time.Second, parallelRequests: false, maxConcurrency: defaultMaxConcurrency}
 
 	for _, opt := range options {
 		opt(rlt)
 	}
 
+	if rlt.parallelRequests {
+		rlt.scheduler = newRequestScheduler(rlt.maxConcurrency, rlt.readDelay, rlt.writeDelay)
+	}
+
 	return rlt
 }
 
@@ -173,6 +604,215 @@ func WithParallelRequests(p bool) RateLimitTransportOption {
 	}
 }
 
+// rateLimitResource returns the API resource, as named in the
+// x-ratelimit-resource header, whose rate limit applies to req.
+func rateLimitResource(req *http.Request) string {
+	switch {
+	case strings.HasSuffix(req.URL.Path, "graphql"):
+		return "graphql"
+	case strings.Contains(req.URL.Path, "/search/"):
+		return "search"
+	}
+	return "core"
+}
+
+func rateLimitFromHeaders(header http.Header) (rateLimitBudget, bool) {
+	remaining, err := strconv.Atoi(header.Get("x-ratelimit-remaining"))
+	if err != nil {
+		return rateLimitBudget{}, false
+	}
+	reset, err := strconv.ParseInt(header.Get("x-ratelimit-reset"), 10, 64)
+	if err != nil {
+		return rateLimitBudget{}, false
+	}
+	return rateLimitBudget{remaining: remaining, reset: time.Unix(reset, 0)}, true
+}
+
+// rateLimitFromGraphQL reads the rateLimit field of a GraphQL response, only
+// present when the query asked for it.
+func rateLimitFromGraphQL(body []byte) (rateLimitBudget, bool) {
+	var data struct {
+		Data struct {
+			RateLimit *struct {
+				Remaining int       `json:"remaining"`
+				ResetAt   time.Time `json:"resetAt"`
+			} `json:"rateLimit"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &data); err != nil || data.Data.RateLimit == nil {
+		return rateLimitBudget{}, false
+	}
+	return rateLimitBudget{remaining: data.Data.RateLimit.Remaining, reset: data.Data.RateLimit.ResetAt}, true
+}
+
+// graphQLRateLimit detects rate limit errors in a GraphQL response and
+// returns how long to wait before retrying, and whether a secondary rate
+// limit was hit.
+func graphQLRateLimit(resp *http.Response, body []byte) (time.Duration, bool, bool) {
+	var data struct {
+		Errors []struct {
+			Type    string `json:"type"`
+			Message string `json:"message"`
+		} `json:"errors"`
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return 0, false, false
+	}
+
+	messages := []string{data.Message}
+	limited := false
+	for _, e := range data.Errors {
+		messages = append(messages, e.Message)
+		limited = limited || e.Type == "RATE_LIMITED"
+	}
+
+	secondary := false
+	for _, m := range messages {
+		m = strings.ToLower(m)
+		secondary = secondary || strings.Contains(m, "secondary rate limit") || strings.Contains(m, "abuse detection")
+		limited = limited || strings.Contains(m, "rate limit exceeded")
+	}
+	if !limited && !secondary {
+		return 0, false, false
+	}
+
+	if retryAfter, ok := retryHint(resp); ok {
+		return retryAfter, secondary, true
+	}
+
+	// Without any hint, GitHub recommends waiting at least one minute.
+	return time.Minute, secondary, true
+}
+
+// WithRateLimitAPIUsage is used to record the time spent waiting on rate limits
+func WithRateLimitAPIUsage(u *apiUsage) RateLimitTransportOption {
+	return func(rlt *RateLimitTransport) {
+		rlt.usage = u
+	}
+}
+
+// WithMaxConcurrency is used to set the maximum number of requests in flight
+// when parallel requests are allowed
+func WithMaxConcurrency(n int) RateLimitTransportOption {
+	return func(rlt *RateLimitTransport) {
+		rlt.maxConcurrency = n
+	}
+}
+
+const (
+	// defaultMaxConcurrency is the default number of parallel requests in flight.
+	defaultMaxConcurrency = 10
+
+	// rateLimitLowWatermark is the fraction of the rate limit below which
+	// concurrency is reduced in proportion to the remaining quota.
+	rateLimitLowWatermark = 0.2
+)
+
+// requestScheduler throttles parallel requests. It bounds the number of
+// requests in flight and paces reads and writes through separate token
+// buckets refilling every readDelay and writeDelay respectively. The
+// concurrency limit adapts to the remaining rate limit quota: it shrinks as
+// x-ratelimit-remaining gets low or a secondary rate limit is hit, and grows
+// back by one slot per response while quota is plentiful.
+type requestScheduler struct {
+	maxConcurrency int
+	readDelay      time.Duration
+	writeDelay     time.Duration
+
+	m         sync.Mutex
+	cond      *sync.Cond
+	inFlight  int
+	limit     int
+	nextRead  time.Time
+	nextWrite time.Time
+}
+
+func newRequestScheduler(maxConcurrency int, readDelay time.Duration, writeDelay time.Duration) *requestScheduler {
+	if maxConcurrency < 1 {
+		maxConcurrency = 1
+	}
+	s := &requestScheduler{
+		maxConcurrency: maxConcurrency,
+		readDelay:      readDelay,
+		writeDelay:     writeDelay,
+		limit:          maxConcurrency,
+	}
+	s.cond = sync.NewCond(&s.m)
+	return s
+}
+
+// acquire waits for a free slot and a token from the read or write bucket.
+func (s *requestScheduler) acquire(write bool) {
+	s.m.Lock()
+	for s.inFlight >= s.limit {
+		s.cond.Wait()
+	}
+	s.inFlight++
+
+	next, delay := &s.nextRead, s.readDelay
+	if write {
+		next, delay = &s.nextWrite, s.writeDelay
+	}
+	start := time.Now()
+	if next.After(start) {
+		start = *next
+	}
+	*next = start.Add(delay)
+	s.m.Unlock()
+
+	if wait := time.Until(start); wait > 0 {
+		log.Printf("[DEBUG] Sleeping %s between operations", wait)
+		time.Sleep(wait)
+	}
+}
+
+func (s *requestScheduler) release() {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.inFlight--
+	s.cond.Broadcast()
+}
+
+// throttle halves the concurrency limit after hitting a secondary rate limit.
+func (s *requestScheduler) throttle() {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.limit = max(s.limit/2, 1)
+	log.Printf("[DEBUG] Reducing parallel requests to %d", s.limit)
+}
+
+// observe adapts the concurrency limit to the rate limit reported by a response.
+func (s *requestScheduler) observe(header http.Header) {
+	remaining, err := strconv.Atoi(header.Get("x-ratelimit-remaining"))
+	if err != nil {
+		return
+	}
+	limit, err := strconv.Atoi(header.Get("x-ratelimit-limit"))
+	if err != nil || limit <= 0 {
+		return
+	}
+
+	target := s.maxConcurrency
+	if quota := float64(remaining) / float64(limit); quota < rateLimitLowWatermark {
+		target = max(int(float64(s.maxConcurrency)*quota/rateLimitLowWatermark), 1)
+	}
+
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	switch {
+	case s.limit > target:
+		s.limit = target
+		log.Printf("[DEBUG] Reducing parallel requests to %d, %d of %d requests remaining", s.limit, remaining, limit)
+	case s.limit < target:
+		s.limit++
+		s.cond.Broadcast()
+	}
+}
+
 // drainBody reads all of b to memory and then returns two equivalent
 // ReadClosers yielding the same bytes.
 func drainBody(b io.ReadCloser) (r1, r2 io.ReadCloser, err error) {
@@ -198,11 +838,27 @@ func isWriteMethod(method string) bool {
 	return false
 }
 
+const (
+	// Defaults of the backoff policy applied between retries.
+	defaultRetryMaxDelay   = 30 * time.Second
+	defaultRetryMultiplier = 2.0
+	defaultRetryJitter     = 0.1
+)
+
+// RetryTransport retries requests answered with a retryable status code,
+// backing off exponentially between attempts unless the server provides
+// a hint about when to retry via the Retry-After or x-ratelimit-reset headers.
 type RetryTransport struct {
-	transport       http.RoundTripper
-	retryDelay      time.Duration
-	maxRetries      int
-	retryableErrors map[int]bool
+	transport          http.RoundTripper
+	retryDelay         time.Duration
+	maxRetryDelay      time.Duration
+	multiplier         float64
+	jitter             float64
+	timeout            time.Duration
+	retryNonIdempotent bool
+	maxRetries         int
+	retryableErrors    map[int]bool
+	usage              *apiUsage
 }
 
 type RetryTransportOption func(*RetryTransport)
@@ -213,7 +869,16 @@ type RetryTransportOption func(*RetryTransport)
 func NewRetryTransport(rt http.RoundTripper, options ...RetryTransportOption) *RetryTransport {
 	// Default to no retry if none is provided
 	defaultErrors := getDefaultRetriableErrors()
-	rlt := &RetryTransport{transport: rt, retryDelay: time.Second, maxRetries: 0, retryableErrors: defaultErrors}
+	rlt := &RetryTransport{
+		transport:          rt,
+		retryDelay:         time.Second,
+		maxRetryDelay:      defaultRetryMaxDelay,
+		multiplier:         defaultRetryMultiplier,
+		jitter:             defaultRetryJitter,
+		retryNonIdempotent: true,
+		maxRetries:         0,
+		retryableErrors:    defaultErrors,
+	}
 
 	for _, opt := range options {
 		opt(rlt)
@@ -227,7 +892,10 @@ func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	var resp *http.Response
 	var dataBuffer *bytes.Reader
 
-	for retry := 0; retry <= t.maxRetries; retry++ {
+	ctx := req.Context()
+	start := time.Now()
+
+	for retry := 0; ; retry++ {
 		// Reset the body
 		// Code from httpretry (https://github.com/ybbus/httpretry/blob/master/roundtripper.go#L60)
 		// if request provides GetBody() we use it as Body,
@@ -267,10 +935,140 @@ func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 			return resp, err
 		}
 
-		time.Sleep(t.retryDelay)
+		if retry >= t.maxRetries || ctx.Err() != nil {
+			return resp, err
+		}
+
+		if !t.retryNonIdempotent && !isReplaySafe(req, dataBuffer) {
+			log.Printf("[DEBUG] Not retrying non-idempotent %s request to %s", req.Method, req.URL)
+			return resp, err
+		}
+
+		delay := t.calculateRetryDelay(retry, resp)
+		if !t.withinDeadline(ctx, start, delay) {
+			log.Printf("[DEBUG] Not retrying %s request to %s, waiting %s would exceed its deadline", req.Method, req.URL, delay)
+			return resp, err
+		}
+
+		// The response is discarded in favour of the next attempt.
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		log.Printf("[DEBUG] Retrying %s request to %s in %s (retry %d of %d)", req.Method, req.URL, delay, retry+1, t.maxRetries)
+		t.usage.recordRetry()
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// calculateRetryDelay returns the time to wait before the given retry. Hints
+// provided by the server take precedence over the exponential backoff, but
+// both are capped by the maximum delay.
+func (t *RetryTransport) calculateRetryDelay(retry int, resp *http.Response) time.Duration {
+	if hint, ok := retryHint(resp); ok {
+		if t.maxRetryDelay > 0 && hint > t.maxRetryDelay {
+			return t.maxRetryDelay
+		}
+		return hint
+	}
+
+	delay := float64(t.retryDelay) * math.Pow(t.multiplier, float64(retry))
+	if t.jitter > 0 {
+		delay += delay * t.jitter * (2*rand.Float64() - 1) // #nosec G404
+	}
+	if t.maxRetryDelay > 0 && delay > float64(t.maxRetryDelay) {
+		delay = float64(t.maxRetryDelay)
+	}
+
+	return time.Duration(delay)
+}
+
+// withinDeadline reports whether waiting for delay leaves time for another
+// attempt before the request's context deadline or the configured timeout.
+func (t *RetryTransport) withinDeadline(ctx context.Context, start time.Time, delay time.Duration) bool {
+	next := time.Now().Add(delay)
+	if deadline, ok := ctx.Deadline(); ok && next.After(deadline) {
+		return false
+	}
+	if t.timeout > 0 && next.After(start.Add(t.timeout)) {
+		return false
+	}
+	return true
+}
+
+// retryHint reads how long to wait before retrying from the Retry-After
+// header, either in seconds or as a date, or from x-ratelimit-reset once the
+// rate limit is exhausted.
+func retryHint(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+
+	if v := resp.Header.Get("Retry-After"); v != "" {
+		if seconds, err := strconv.Atoi(v); err == nil {
+			return time.Duration(seconds) * time.Second, true
+		}
+		if date, err := http.ParseTime(v); err == nil {
+			return max(time.Until(date), 0), true
+		}
+	}
+
+	if resp.Header.Get("x-ratelimit-remaining") == "0" {
+		if reset, err := strconv.ParseInt(resp.Header.Get("x-ratelimit-reset"), 10, 64); err == nil {
+			return max(time.Until(time.Unix(reset, 0)), 0), true
+		}
 	}
 
-	return resp, err
+	return 0, false
+}
+
+// isReplaySafe reports whether sending req again cannot apply a change twice.
+// GraphQL queries are sent as POST requests but, unlike mutations, are safe.
+// A GraphQL document is only considered a query when every operation in it is.
+func isReplaySafe(req *http.Request, body *bytes.Reader) bool {
+	switch req.Method {
+	case "GET", "HEAD", "OPTIONS", "PUT", "DELETE":
+		return true
+	case "POST":
+		if !strings.HasSuffix(req.URL.Path, "graphql") {
+			return false
+		}
+	default:
+		return false
+	}
+
+	var data []byte
+	if req.GetBody != nil {
+		rc, err := req.GetBody()
+		if err != nil {
+			return false
+		}
+		defer rc.Close()
+		if data, err = io.ReadAll(rc); err != nil {
+			return false
+		}
+	} else if body != nil {
+		data = make([]byte, body.Size())
+		if _, err := body.ReadAt(data, 0); err != nil && err != io.EOF {
+			return false
+		}
+	}
+
+	var query struct {
+		Query string `json:"query"`
+	}
+	if err := json.Unmarshal(data, &query); err != nil {
+		return false
+	}
+
+	return isGraphQLQuery(query.Query)
 }
 
 // WithMaxRetries is used to set the max number of retries when encountering an error
@@ -287,9 +1085,103 @@ func WithRetryableErrors(d map[int]bool) RetryTransportOption {
 	}
 }
 
-// WithRetryDelay is used to set the delay between requests for retrying
+// WithRetryDelay is used to set the delay before the first retry
 func WithRetryDelay(d time.Duration) RetryTransportOption {
 	return func(rt *RetryTransport) {
 		rt.retryDelay = d
 	}
 }
+
+// WithMaxRetryDelay is used to cap the delay between retries
+func WithMaxRetryDelay(d time.Duration) RetryTransportOption {
+	return func(rt *RetryTransport) {
+		rt.maxRetryDelay = d
+	}
+}
+
+// WithRetryMultiplier is used to set the factor the delay grows by with each retry
+func WithRetryMultiplier(m float64) RetryTransportOption {
+	return func(rt *RetryTransport) {
+		rt.multiplier = m
+	}
+}
+
+// WithRetryJitter is used to set the fraction by which delays are randomized
+func WithRetryJitter(j float64) RetryTransportOption {
+	return func(rt *RetryTransport) {
+		rt.jitter = j
+	}
+}
+
+// WithRetryTimeout is used to bound the total time spent retrying a request
+func WithRetryTimeout(d time.Duration) RetryTransportOption {
+	return func(rt *RetryTransport) {
+		rt.timeout = d
+	}
+}
+
+// WithRetryAPIUsage is used to count retries
+func WithRetryAPIUsage(u *apiUsage) RetryTransportOption {
+	return func(rt *RetryTransport) {
+		rt.usage = u
+	}
+}
+
+// WithRetryNonIdempotent is used to allow retrying requests that are not safe to replay
+func WithRetryNonIdempotent(r bool) RetryTransportOption {
+	return func(rt *RetryTransport) {
+		rt.retryNonIdempotent = r
+	}
+}
+
+// tokenInvalidator is implemented by token sources able to discard a token
+// the API has rejected, see appInstallationTokenSource.
+type tokenInvalidator interface {
+	invalidate(accessToken string)
+}
+
+// tokenRefreshTransport retries a request once with a fresh token when the
+// API answers 401 Unauthorized, e.g. because a GitHub App installation token
+// was revoked or expired earlier than announced.
+type tokenRefreshTransport struct {
+	transport http.RoundTripper
+	source    tokenInvalidator
+}
+
+// NewTokenRefreshTransport wraps an oauth2.Transport whose token source
+// supports invalidation.
+func NewTokenRefreshTransport(rt http.RoundTripper, source tokenInvalidator) *tokenRefreshTransport {
+	return &tokenRefreshTransport{transport: rt, source: source}
+}
+
+func (trt *tokenRefreshTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// The request may only be replayed if its body can be read again.
+	canRetry := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+
+	resp, err := trt.transport.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized || !canRetry {
+		return resp, err
+	}
+
+	// The Authorization header is set on a clone of req by oauth2.Transport,
+	// so the rejected token is read back from the response's request.
+	rejected := strings.TrimPrefix(resp.Request.Header.Get("Authorization"), "Bearer ")
+	if rejected == "" {
+		return resp, err
+	}
+
+	log.Printf("[DEBUG] Received 401 from %s, refreshing token and retrying", req.URL)
+	trt.source.invalidate(rejected)
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	retryReq := req.Clone(req.Context())
+	if req.GetBody != nil {
+		retryReq.Body, err = req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return trt.transport.RoundTrip(retryReq)
+}
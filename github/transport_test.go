@@ -1,6 +1,7 @@
 package github
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
@@ -8,10 +9,13 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/go-github/v67/github"
+	"github.com/shurcooL/githubv4"
+	"golang.org/x/oauth2"
 )
 
 func TestEtagTransport(t *testing.T) {
@@ -46,6 +50,103 @@ func TestEtagTransport(t *testing.T) {
 	}
 }
 
+func TestCacheTransport(t *testing.T) {
+	ts := githubApiMock([]*mockResponse{
+		{
+			ExpectedUri: "/repos/test/blah",
+			ResponseHeaders: map[string]string{
+				"Etag": `"abc"`,
+			},
+			ResponseBody: `{"id": 1234}`,
+			StatusCode:   200,
+		},
+		{
+			ExpectedUri: "/repos/test/blah",
+			ExpectedHeaders: map[string]string{
+				"If-None-Match": `"abc"`,
+			},
+			StatusCode: 304,
+		},
+		{
+			ExpectedUri: "/repos/test/blah",
+			ExpectedHeaders: map[string]string{
+				"If-None-Match": `"abc"`,
+			},
+			StatusCode: 304,
+		},
+	})
+	defer ts.Close()
+
+	dir := t.TempDir()
+	newClient := func() *github.Client {
+		httpClient := &http.Client{Transport: NewCacheTransport(http.DefaultTransport, "test", WithCacheDir(dir))}
+		client := github.NewClient(httpClient)
+		u, _ := url.Parse(ts.URL + "/")
+		client.BaseURL = u
+		return client
+	}
+
+	client := newClient()
+	for _, description := range []string{"fetches the response", "serves a 304 from memory"} {
+		r, _, err := client.Repositories.Get(context.Background(), "test", "blah")
+		if err != nil {
+			t.Fatalf("%s: %s", description, err)
+		}
+		if r.GetID() != 1234 {
+			t.Fatalf("%s: expected ID to be 1234, got: %d", description, r.GetID())
+		}
+	}
+
+	r, _, err := newClient().Repositories.Get(context.Background(), "test", "blah")
+	if err != nil {
+		t.Fatalf("serves a 304 from disk: %s", err)
+	}
+	if r.GetID() != 1234 {
+		t.Fatalf("serves a 304 from disk: expected ID to be 1234, got: %d", r.GetID())
+	}
+}
+
+func TestCacheTransport_maxBytes(t *testing.T) {
+	ct := NewCacheTransport(http.DefaultTransport, "token:a", WithCacheMaxBytes(10))
+
+	for _, key := range []string{"a", "b", "c"} {
+		ct.store(key, &cacheEntry{StatusCode: 200, Body: []byte("1234")})
+	}
+	ct.load("b")
+	ct.store("d", &cacheEntry{StatusCode: 200, Body: []byte("1234")})
+
+	if ct.load("a") != nil || ct.load("c") != nil {
+		t.Error("Expected the least recently used entries to be evicted")
+	}
+	if ct.load("b") == nil || ct.load("d") == nil {
+		t.Error("Expected the recently used entries to be kept")
+	}
+	if ct.size > 10 {
+		t.Errorf("Expected at most 10 bytes to be cached, got %d", ct.size)
+	}
+
+	ct.store("e", &cacheEntry{StatusCode: 200, Body: []byte("12345678901")})
+	if ct.load("e") != nil {
+		t.Error("Expected an entry larger than the cache not to be kept")
+	}
+}
+
+func TestCacheTransport_key(t *testing.T) {
+	req := httptest.NewRequest("GET", "https://api.github.com/repos/test/blah", nil)
+
+	first := NewCacheTransport(http.DefaultTransport, "token:a")
+	second := NewCacheTransport(http.DefaultTransport, "token:b")
+	if first.key(req) == second.key(req) {
+		t.Fatal("Expected responses for different credentials to use different keys")
+	}
+
+	raw := req.Clone(req.Context())
+	raw.Header.Set("Accept", "application/vnd.github.raw")
+	if first.key(req) == first.key(raw) {
+		t.Fatal("Expected responses for different media types to use different keys")
+	}
+}
+
 func githubApiMock(responseSequence []*mockResponse) *httptest.Server {
 	position := github.Int(0)
 	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -272,6 +373,140 @@ func TestRateLimitTransport_abuseLimit_post_error(t *testing.T) {
 		t.Fatalf("Expected message %q, got: %q", expectedMessage, ghErr.Message)
 	}
 }
+func TestRateLimitTransport_graphQLRateLimit(t *testing.T) {
+	ts := githubApiMock([]*mockResponse{
+		{
+			ExpectedUri:    "/graphql",
+			ExpectedMethod: "POST",
+			ExpectedBody:   []byte(`{"query":"{viewer{login}}"}` + "\n"),
+			ResponseHeaders: map[string]string{
+				"x-ratelimit-resource":  "graphql",
+				"x-ratelimit-remaining": "0",
+				"x-ratelimit-reset":     fmt.Sprintf("%d", time.Now().Add(time.Second).Unix()),
+			},
+			ResponseBody: `{
+  "errors": [
+    {
+      "type": "RATE_LIMITED",
+      "message": "API rate limit exceeded for user ID 1."
+    }
+  ]
+}`,
+			StatusCode: 200,
+		},
+		{
+			ExpectedUri:    "/graphql",
+			ExpectedMethod: "POST",
+			ExpectedBody:   []byte(`{"query":"{viewer{login}}"}` + "\n"),
+			ResponseBody:   `{"data": {"viewer": {"login": "octocat"}}}`,
+			StatusCode:     200,
+		},
+	})
+	defer ts.Close()
+
+	// The body is consumed below the rate limit transport, as net/http would
+	// otherwise rewind it on its own.
+	httpClient := &http.Client{Transport: NewRateLimitTransport(bodyConsumingTransport{})}
+	client := githubv4.NewEnterpriseClient(ts.URL+"/graphql", httpClient)
+
+	var query struct {
+		Viewer struct {
+			Login githubv4.String
+		}
+	}
+	err := client.Query(context.Background(), &query, nil)
+	if err != nil {
+		t.Fatalf("Expected error to be nil, got %v", err)
+	}
+
+	if query.Viewer.Login != "octocat" {
+		t.Fatalf("Expected login to be octocat, got: %s", query.Viewer.Login)
+	}
+}
+
+// bodyConsumingTransport reads the body of requests and sends a copy of them
+// which cannot be rewound.
+type bodyConsumingTransport struct{}
+
+func (bodyConsumingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+	}
+	sent := req.Clone(req.Context())
+	sent.Body = io.NopCloser(bytes.NewReader(body))
+	sent.GetBody = nil
+	sent.ContentLength = int64(len(body))
+	return http.DefaultTransport.RoundTrip(sent)
+}
+
+func TestGraphQLRateLimit(t *testing.T) {
+	testCases := []struct {
+		body        string
+		limited     bool
+		secondary   bool
+		description string
+	}{
+		{
+			body:        `{"data": {"viewer": {"login": "octocat"}}}`,
+			description: "successful response",
+		},
+		{
+			body:        `{"errors": [{"type": "NOT_FOUND", "message": "Could not resolve to a Repository."}]}`,
+			description: "unrelated error",
+		},
+		{
+			body:        `{"errors": [{"type": "RATE_LIMITED", "message": "API rate limit exceeded for user ID 1."}]}`,
+			limited:     true,
+			description: "primary rate limit",
+		},
+		{
+			body:        `{"documentation_url": "https://docs.github.com/graphql/overview/rate-limits-and-node-limits-for-the-graphql-api#secondary-rate-limits", "message": "You have exceeded a secondary rate limit. Please wait a few minutes before you try again."}`,
+			limited:     true,
+			secondary:   true,
+			description: "secondary rate limit",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			resp := &http.Response{Header: http.Header{"Retry-After": []string{"30"}}}
+			retryAfter, secondary, limited := graphQLRateLimit(resp, []byte(tc.body))
+			if limited != tc.limited || secondary != tc.secondary {
+				t.Fatalf("Expected limited=%v secondary=%v, got limited=%v secondary=%v", tc.limited, tc.secondary, limited, secondary)
+			}
+			if limited && retryAfter != 30*time.Second {
+				t.Fatalf("Expected to retry after 30s, got: %s", retryAfter)
+			}
+		})
+	}
+}
+
+func TestRateLimitTransport_budget(t *testing.T) {
+	rlt := NewRateLimitTransport(http.DefaultTransport)
+
+	header := http.Header{}
+	header.Set("x-ratelimit-resource", "graphql")
+	header.Set("x-ratelimit-remaining", "0")
+	header.Set("x-ratelimit-reset", fmt.Sprintf("%d", time.Now().Add(time.Minute).Unix()))
+	rlt.updateBudget("graphql", header, nil)
+
+	if wait := rlt.budgetWait("graphql"); wait <= 0 {
+		t.Fatalf("Expected to wait for the exhausted graphql budget, got: %s", wait)
+	}
+	if wait := rlt.budgetWait("core"); wait != 0 {
+		t.Fatalf("Expected not to wait for the core budget, got: %s", wait)
+	}
+
+	rlt.updateBudget("graphql", http.Header{}, []byte(`{"data": {"rateLimit": {"remaining": 4999, "resetAt": "2030-01-01T00:00:00Z"}}}`))
+	if wait := rlt.budgetWait("graphql"); wait != 0 {
+		t.Fatalf("Expected not to wait once the graphql budget is replenished, got: %s", wait)
+	}
+}
+
 func TestRateLimitTransport_smart_lock(t *testing.T) {
 	t.Run("With parallelRequests true it does not lock the rate limit transport", func(t *testing.T) {
 		rlt := NewRateLimitTransport(http.DefaultTransport, WithParallelRequests(true))
@@ -342,6 +577,75 @@ func TestRateLimitTransport_smart_lock(t *testing.T) {
 	})
 }
 
+func TestRequestScheduler(t *testing.T) {
+	t.Run("bounds the number of requests in flight", func(t *testing.T) {
+		s := newRequestScheduler(2, 0, 0)
+		s.acquire(false)
+		s.acquire(true)
+
+		isSuccess := make(chan bool)
+		go func() {
+			s.acquire(false)
+			isSuccess <- true
+		}()
+		select {
+		case <-isSuccess:
+			t.Fatalf("Expected get stuck waiting but it acquired a slot successfully")
+		case <-time.After(100 * time.Millisecond):
+		}
+
+		s.release()
+		select {
+		case <-isSuccess:
+		case <-time.After(100 * time.Millisecond):
+			t.Fatalf("Expected to acquire the released slot, waited 100 milliseconds unsuccessfully")
+		}
+	})
+
+	t.Run("paces reads and writes separately", func(t *testing.T) {
+		s := newRequestScheduler(10, 0, time.Hour)
+		s.acquire(true)
+
+		isSuccess := make(chan bool)
+		go func() {
+			s.acquire(false)
+			isSuccess <- true
+		}()
+		select {
+		case <-isSuccess:
+		case <-time.After(100 * time.Millisecond):
+			t.Fatalf("Expected a read not to wait for the write delay")
+		}
+	})
+
+	t.Run("adapts concurrency to the remaining rate limit", func(t *testing.T) {
+		s := newRequestScheduler(10, 0, 0)
+
+		header := http.Header{}
+		header.Set("x-ratelimit-limit", "5000")
+		header.Set("x-ratelimit-remaining", "100")
+		s.observe(header)
+		if s.limit != 1 {
+			t.Fatalf("Expected concurrency to drop to 1, got: %d", s.limit)
+		}
+
+		header.Set("x-ratelimit-remaining", "4000")
+		s.observe(header)
+		s.observe(header)
+		if s.limit != 3 {
+			t.Fatalf("Expected concurrency to grow back by one per response, got: %d", s.limit)
+		}
+	})
+
+	t.Run("halves concurrency on secondary rate limits", func(t *testing.T) {
+		s := newRequestScheduler(10, 0, 0)
+		s.throttle()
+		if s.limit != 5 {
+			t.Fatalf("Expected concurrency to be halved to 5, got: %d", s.limit)
+		}
+	})
+}
+
 func TestRetryTransport_retry_post_error(t *testing.T) {
 	ts := githubApiMock([]*mockResponse{
 		{
@@ -461,6 +765,214 @@ func TestRetryTransport_retry_post_success(t *testing.T) {
 	}
 }
 
+func TestRetryTransport_retry_after(t *testing.T) {
+	ts := githubApiMock([]*mockResponse{
+		{
+			ExpectedUri: "/repos/test/blah",
+			ResponseHeaders: map[string]string{
+				"Retry-After": "1",
+			},
+			ResponseBody: `{
+  "message": "service unavailable"
+}`,
+			StatusCode: 503,
+		},
+		{
+			ExpectedUri:  "/repos/test/blah",
+			ResponseBody: `{"id": 1234}`,
+			StatusCode:   200,
+		},
+	})
+	defer ts.Close()
+
+	httpClient := &http.Client{
+		Transport: NewRetryTransport(http.DefaultTransport, WithMaxRetries(1), WithRetryDelay(10*time.Millisecond)),
+	}
+
+	client := github.NewClient(httpClient)
+	u, _ := url.Parse(ts.URL + "/")
+	client.BaseURL = u
+
+	start := time.Now()
+	r, _, err := client.Repositories.Get(context.Background(), "test", "blah")
+	if err != nil {
+		t.Fatalf("Expected error to be nil, got %v", err)
+	}
+
+	if r.GetID() != 1234 {
+		t.Fatalf("Expected ID to be 1234, got: %d", r.GetID())
+	}
+
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Fatalf("Expected to wait for the Retry-After delay of 1s, waited %s", elapsed)
+	}
+}
+
+func TestRetryTransport_non_idempotent(t *testing.T) {
+	ts := githubApiMock([]*mockResponse{
+		{
+			ExpectedUri:    "/orgs/tada/repos",
+			ExpectedMethod: "POST",
+			ResponseBody: `{
+  "message": "bad gateway"
+}`,
+			StatusCode: 502,
+		},
+		{
+			ExpectedUri:    "/orgs/tada/repos",
+			ExpectedMethod: "POST",
+			ResponseBody: `{
+  "message": "Resource created"
+}`,
+			StatusCode: 201,
+		},
+	})
+	defer ts.Close()
+
+	httpClient := &http.Client{
+		Transport: NewRetryTransport(http.DefaultTransport, WithMaxRetries(1), WithRetryDelay(10*time.Millisecond), WithRetryNonIdempotent(false)),
+	}
+
+	client := github.NewClient(httpClient)
+	u, _ := url.Parse(ts.URL + "/")
+	client.BaseURL = u
+
+	_, _, err := client.Repositories.Create(context.Background(), "tada", &github.Repository{
+		Name: github.String("radek-example-48"),
+	})
+	if err == nil {
+		t.Fatal("Expected the POST request not to be retried")
+	}
+
+	ghErr, ok := err.(*github.ErrorResponse)
+	if !ok || ghErr.Response.StatusCode != 502 {
+		t.Fatalf("Expected a 502 github.ErrorResponse, got: %#v", err)
+	}
+}
+
+func TestRetryTransport_calculateRetryDelay(t *testing.T) {
+	rt := NewRetryTransport(http.DefaultTransport,
+		WithRetryDelay(time.Second), WithMaxRetryDelay(5*time.Second), WithRetryMultiplier(2), WithRetryJitter(0))
+
+	expectedDelays := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second}
+	for retry, expected := range expectedDelays {
+		if delay := rt.calculateRetryDelay(retry, nil); delay != expected {
+			t.Errorf("Expected delay before retry %d to be %s, got: %s", retry, expected, delay)
+		}
+	}
+
+	resp := &http.Response{Header: http.Header{}}
+	resp.Header.Set("x-ratelimit-remaining", "0")
+	resp.Header.Set("x-ratelimit-reset", fmt.Sprintf("%d", time.Now().Add(time.Minute).Unix()))
+	if delay := rt.calculateRetryDelay(0, resp); delay != 5*time.Second {
+		t.Errorf("Expected the x-ratelimit-reset delay to be capped to 5s, got: %s", delay)
+	}
+
+	resp = &http.Response{Header: http.Header{}}
+	resp.Header.Set("Retry-After", "3")
+	if delay := rt.calculateRetryDelay(0, resp); delay != 3*time.Second {
+		t.Errorf("Expected delay to honor Retry-After, got: %s", delay)
+	}
+}
+
+func TestIsReplaySafe(t *testing.T) {
+	testCases := []struct {
+		method      string
+		path        string
+		body        string
+		safe        bool
+		description string
+	}{
+		{method: "GET", path: "/repos/test/blah", safe: true, description: "GET request"},
+		{method: "PUT", path: "/repos/test/blah/topics", safe: true, description: "PUT request"},
+		{method: "POST", path: "/orgs/tada/repos", safe: false, description: "REST POST request"},
+		{method: "PATCH", path: "/repos/test/blah", safe: false, description: "PATCH request"},
+		{method: "POST", path: "/graphql", body: `{"query":"query{viewer{login}}"}`, safe: true, description: "GraphQL query"},
+		{method: "POST", path: "/api/graphql", body: `{"query":"mutation($input:AddStarInput!){addStar(input:$input){clientMutationId}}"}`, safe: false, description: "GraphQL mutation"},
+		{method: "POST", path: "/graphql", body: `{"query":"# star\nmutation{addStar(input:{starrableId:\"R_1\"}){clientMutationId}}"}`, safe: false, description: "GraphQL mutation after a comment"},
+		{method: "POST", path: "/graphql", body: `{"query":"fragment F on Starrable{id} mutation{addStar(input:{starrableId:\"R_1\"}){starrable{...F}}}"}`, safe: false, description: "GraphQL mutation after a fragment"},
+		{method: "POST", path: "/graphql", body: `not json`, safe: false, description: "GraphQL request that cannot be read"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+			req.GetBody = func() (io.ReadCloser, error) {
+				return io.NopCloser(strings.NewReader(tc.body)), nil
+			}
+			if safe := isReplaySafe(req, nil); safe != tc.safe {
+				t.Errorf("Expected replay safe to be %v, got %v", tc.safe, safe)
+			}
+		})
+	}
+}
+
+type testTokenSource struct {
+	tokens      []string
+	invalidated []string
+}
+
+func (ts *testTokenSource) Token() (*oauth2.Token, error) {
+	return &oauth2.Token{AccessToken: ts.tokens[0], TokenType: "Bearer"}, nil
+}
+
+func (ts *testTokenSource) invalidate(accessToken string) {
+	ts.invalidated = append(ts.invalidated, accessToken)
+	ts.tokens = ts.tokens[1:]
+}
+
+func TestTokenRefreshTransport_unauthorized(t *testing.T) {
+	ts := githubApiMock([]*mockResponse{
+		{
+			ExpectedUri:    "/orgs/tada/repos",
+			ExpectedMethod: "POST",
+			ExpectedHeaders: map[string]string{
+				"Authorization": "Bearer revoked",
+			},
+			ResponseBody: `{
+  "message": "Bad credentials"
+}`,
+			StatusCode: 401,
+		},
+		{
+			ExpectedUri:    "/orgs/tada/repos",
+			ExpectedMethod: "POST",
+			ExpectedHeaders: map[string]string{
+				"Authorization": "Bearer valid",
+			},
+			ExpectedBody: []byte(`{"name":"radek-example-48","description":""}
+`),
+			ResponseBody: `{
+  "message": "Resource created"
+}`,
+			StatusCode: 201,
+		},
+	})
+	defer ts.Close()
+
+	source := &testTokenSource{tokens: []string{"revoked", "valid"}}
+	httpClient := &http.Client{
+		Transport: NewTokenRefreshTransport(&oauth2.Transport{Source: source}, source),
+	}
+
+	client := github.NewClient(httpClient)
+	u, _ := url.Parse(ts.URL + "/")
+	client.BaseURL = u
+
+	ctx := context.WithValue(context.Background(), ctxId, t.Name())
+	_, _, err := client.Repositories.Create(ctx, "tada", &github.Repository{
+		Name:        github.String("radek-example-48"),
+		Description: github.String(""),
+	})
+	if err != nil {
+		t.Fatalf("Expected error to be nil, got %v", err)
+	}
+
+	if len(source.invalidated) != 1 || source.invalidated[0] != "revoked" {
+		t.Fatalf("Expected the revoked token to be invalidated, got: %v", source.invalidated)
+	}
+}
+
 type mockResponse struct {
 	ExpectedUri     string
 	ExpectedMethod  string
@@ -0,0 +1,279 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// The settings below are shared by organizations and repositories, under
+// orgs/{org}/actions or repos/{owner}/{repo}/actions. go-github does not
+// model them yet.
+
+type actionsArtifactAndLogRetention struct {
+	Days int `json:"days"`
+}
+
+type actionsCacheRetentionLimit struct {
+	MaxCacheRetentionDays int `json:"max_cache_retention_days"`
+}
+
+type actionsCacheStorageLimit struct {
+	MaxCacheSizeGB int `json:"max_cache_size_gb"`
+}
+
+type actionsForkPRContributorApproval struct {
+	ApprovalPolicy string `json:"approval_policy"`
+}
+
+type actionsForkPRWorkflowsPrivateRepos struct {
+	RunWorkflowsFromForkPullRequests  bool `json:"run_workflows_from_fork_pull_requests"`
+	SendWriteTokensToWorkflows        bool `json:"send_write_tokens_to_workflows"`
+	SendSecretsAndVariables           bool `json:"send_secrets_and_variables"`
+	RequireApprovalForForkPRWorkflows bool `json:"require_approval_for_fork_pr_workflows"`
+}
+
+// actionsSetting is the body of a setting and its path under the prefix.
+type actionsSetting struct {
+	path string
+	body interface{}
+}
+
+// actionsSettingsSchema returns the schema of the settings, which are only
+// managed when they are configured.
+func actionsSettingsSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"artifact_and_log_retention_days": {
+			Type:             schema.TypeInt,
+			Optional:         true,
+			Computed:         true,
+			ValidateDiagFunc: toDiagFunc(validation.IntBetween(1, 400), "artifact_and_log_retention_days"),
+			Description:      "The number of days artifacts and logs of workflow runs are retained for.",
+		},
+		"cache_retention_days": {
+			Type:             schema.TypeInt,
+			Optional:         true,
+			Computed:         true,
+			ValidateDiagFunc: toDiagFunc(validation.IntAtLeast(1), "cache_retention_days"),
+			Description:      "The number of days an unused Actions cache entry is retained for.",
+		},
+		"cache_storage_limit_gb": {
+			Type:             schema.TypeInt,
+			Optional:         true,
+			Computed:         true,
+			ValidateDiagFunc: toDiagFunc(validation.IntAtLeast(1), "cache_storage_limit_gb"),
+			Description:      "The maximum size of the Actions cache of a repository, in gigabytes.",
+		},
+		"fork_pr_contributor_approval": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Computed:         true,
+			ValidateDiagFunc: validateValueFunc([]string{"first_time_contributors_new_to_github", "first_time_contributors", "all_external_contributors"}),
+			Description:      "The outside contributors whose fork pull request workflows require approval to run. Must be one of 'first_time_contributors_new_to_github', 'first_time_contributors' or 'all_external_contributors'.",
+		},
+		"private_fork_pr_workflows": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "The settings of the workflows run from fork pull requests of private repositories.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"run_workflows_from_fork_pull_requests": {
+						Type:        schema.TypeBool,
+						Required:    true,
+						Description: "Whether workflows are run from fork pull requests.",
+					},
+					"send_write_tokens_to_workflows": {
+						Type:        schema.TypeBool,
+						Optional:    true,
+						Default:     false,
+						Description: "Whether workflows run from fork pull requests get a token with write permissions.",
+					},
+					"send_secrets_and_variables": {
+						Type:        schema.TypeBool,
+						Optional:    true,
+						Default:     false,
+						Description: "Whether secrets and variables are sent to workflows run from fork pull requests.",
+					},
+					"require_approval_for_fork_pr_workflows": {
+						Type:        schema.TypeBool,
+						Optional:    true,
+						Default:     false,
+						Description: "Whether workflows run from fork pull requests require approval to run.",
+					},
+				},
+			},
+		},
+	}
+}
+
+// updateActionsSettings sets the configured settings. The prefix is
+// orgs/{org} or repos/{owner}/{repo}.
+func updateActionsSettings(ctx context.Context, d *schema.ResourceData, client *github.Client, prefix string) error {
+	var settings []actionsSetting
+
+	if v, ok := d.GetOk("artifact_and_log_retention_days"); ok && d.HasChange("artifact_and_log_retention_days") {
+		settings = append(settings, actionsSetting{"actions/permissions/artifact-and-log-retention", &actionsArtifactAndLogRetention{Days: v.(int)}})
+	}
+	if v, ok := d.GetOk("cache_retention_days"); ok && d.HasChange("cache_retention_days") {
+		settings = append(settings, actionsSetting{"actions/cache/retention-limit", &actionsCacheRetentionLimit{MaxCacheRetentionDays: v.(int)}})
+	}
+	if v, ok := d.GetOk("cache_storage_limit_gb"); ok && d.HasChange("cache_storage_limit_gb") {
+		settings = append(settings, actionsSetting{"actions/cache/storage-limit", &actionsCacheStorageLimit{MaxCacheSizeGB: v.(int)}})
+	}
+	if v, ok := d.GetOk("fork_pr_contributor_approval"); ok && d.HasChange("fork_pr_contributor_approval") {
+		settings = append(settings, actionsSetting{"actions/permissions/fork-pr-contributor-approval", &actionsForkPRContributorApproval{ApprovalPolicy: v.(string)}})
+	}
+	if d.HasChange("private_fork_pr_workflows") {
+		// Removing the block turns running workflows from fork pull requests off.
+		settings = append(settings, actionsSetting{"actions/permissions/fork-pr-workflows-private-repos", expandActionsForkPRWorkflowsPrivateRepos(d)})
+	}
+
+	for _, setting := range settings {
+		req, err := client.NewRequest("PUT", fmt.Sprintf("%s/%s", prefix, setting.path), setting.body)
+		if err != nil {
+			return err
+		}
+		if _, err = client.Do(ctx, req, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readActionsSettings reads every setting, so that they are all known once
+// imported. As some of them are not available to every organization or
+// repository, the ones that are not configured are skipped when GitHub
+// refuses to return them.
+func readActionsSettings(ctx context.Context, d *schema.ResourceData, client *github.Client, prefix string) error {
+	get := func(key, path string, v interface{}) (bool, error) {
+		req, err := client.NewRequest("GET", fmt.Sprintf("%s/%s", prefix, path), nil)
+		if err != nil {
+			return false, err
+		}
+		_, err = client.Do(ctx, req, v)
+		if ghErr, ok := err.(*github.ErrorResponse); ok && !isActionsSettingConfigured(d, key) {
+			switch ghErr.Response.StatusCode {
+			case http.StatusForbidden, http.StatusNotFound, http.StatusConflict, http.StatusUnprocessableEntity:
+				log.Printf("[DEBUG] Skipping Actions setting %s/%s: %s", prefix, path, err)
+				return false, nil
+			}
+		}
+		return err == nil, err
+	}
+
+	retention := new(actionsArtifactAndLogRetention)
+	if ok, err := get("artifact_and_log_retention_days", "actions/permissions/artifact-and-log-retention", retention); err != nil {
+		return err
+	} else if ok {
+		if err = d.Set("artifact_and_log_retention_days", retention.Days); err != nil {
+			return err
+		}
+	}
+
+	retentionLimit := new(actionsCacheRetentionLimit)
+	if ok, err := get("cache_retention_days", "actions/cache/retention-limit", retentionLimit); err != nil {
+		return err
+	} else if ok {
+		if err = d.Set("cache_retention_days", retentionLimit.MaxCacheRetentionDays); err != nil {
+			return err
+		}
+	}
+
+	storageLimit := new(actionsCacheStorageLimit)
+	if ok, err := get("cache_storage_limit_gb", "actions/cache/storage-limit", storageLimit); err != nil {
+		return err
+	} else if ok {
+		if err = d.Set("cache_storage_limit_gb", storageLimit.MaxCacheSizeGB); err != nil {
+			return err
+		}
+	}
+
+	approval := new(actionsForkPRContributorApproval)
+	if ok, err := get("fork_pr_contributor_approval", "actions/permissions/fork-pr-contributor-approval", approval); err != nil {
+		return err
+	} else if ok {
+		if err = d.Set("fork_pr_contributor_approval", approval.ApprovalPolicy); err != nil {
+			return err
+		}
+	}
+
+	// The block is left out while the settings are off and it is not
+	// configured, as removing it is how they are turned off.
+	workflows := new(actionsForkPRWorkflowsPrivateRepos)
+	if ok, err := get("private_fork_pr_workflows", "actions/permissions/fork-pr-workflows-private-repos", workflows); err != nil {
+		return err
+	} else if ok && (*workflows != actionsForkPRWorkflowsPrivateRepos{} || isActionsSettingConfigured(d, "private_fork_pr_workflows")) {
+		if err = d.Set("private_fork_pr_workflows", flattenActionsForkPRWorkflowsPrivateRepos(workflows)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func isActionsSettingConfigured(d *schema.ResourceData, key string) bool {
+	if key == "private_fork_pr_workflows" {
+		return len(d.Get(key).([]interface{})) > 0
+	}
+	_, ok := d.GetOk(key)
+	return ok
+}
+
+// resetActionsSettings restores the default fork pull request settings. The
+// retention and cache limits are left as they are, as their defaults depend
+// on the limits of the enterprise or organization.
+func resetActionsSettings(ctx context.Context, d *schema.ResourceData, client *github.Client, prefix string) error {
+	var settings []actionsSetting
+
+	if _, ok := d.GetOk("fork_pr_contributor_approval"); ok {
+		settings = append(settings, actionsSetting{"actions/permissions/fork-pr-contributor-approval", &actionsForkPRContributorApproval{ApprovalPolicy: "first_time_contributors"}})
+	}
+	if len(d.Get("private_fork_pr_workflows").([]interface{})) > 0 {
+		settings = append(settings, actionsSetting{"actions/permissions/fork-pr-workflows-private-repos", &actionsForkPRWorkflowsPrivateRepos{}})
+	}
+
+	for _, setting := range settings {
+		req, err := client.NewRequest("PUT", fmt.Sprintf("%s/%s", prefix, setting.path), setting.body)
+		if err != nil {
+			return err
+		}
+		if _, err = client.Do(ctx, req, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func expandActionsForkPRWorkflowsPrivateRepos(d *schema.ResourceData) *actionsForkPRWorkflowsPrivateRepos {
+	config := d.Get("private_fork_pr_workflows").([]interface{})
+	if len(config) == 0 || config[0] == nil {
+		return &actionsForkPRWorkflowsPrivateRepos{}
+	}
+
+	data := config[0].(map[string]interface{})
+	return &actionsForkPRWorkflowsPrivateRepos{
+		RunWorkflowsFromForkPullRequests:  data["run_workflows_from_fork_pull_requests"].(bool),
+		SendWriteTokensToWorkflows:        data["send_write_tokens_to_workflows"].(bool),
+		SendSecretsAndVariables:           data["send_secrets_and_variables"].(bool),
+		RequireApprovalForForkPRWorkflows: data["require_approval_for_fork_pr_workflows"].(bool),
+	}
+}
+
+func flattenActionsForkPRWorkflowsPrivateRepos(workflows *actionsForkPRWorkflowsPrivateRepos) []interface{} {
+	return []interface{}{
+		map[string]interface{}{
+			"run_workflows_from_fork_pull_requests":  workflows.RunWorkflowsFromForkPullRequests,
+			"send_write_tokens_to_workflows":         workflows.SendWriteTokensToWorkflows,
+			"send_secrets_and_variables":             workflows.SendSecretsAndVariables,
+			"require_approval_for_fork_pr_workflows": workflows.RequireApprovalForForkPRWorkflows,
+		},
+	}
+}
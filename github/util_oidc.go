@@ -0,0 +1,126 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// oidcCustomPropertyClaimPrefix prefixes the claims of the custom properties
+// of repositories included in the OIDC tokens of an organization.
+const oidcCustomPropertyClaimPrefix = "repo_property_"
+
+// oidcClaimKeys are the claims of the OIDC tokens of GitHub Actions known to
+// be allowed in the subject claim. GitHub adds claims over time, so other
+// keys only log a warning.
+var oidcClaimKeys = []string{
+	"actor",
+	"actor_id",
+	"base_ref",
+	"check_run_id",
+	"context",
+	"enterprise",
+	"enterprise_id",
+	"environment",
+	"environment_node_id",
+	"event_name",
+	"head_ref",
+	"job_workflow_ref",
+	"job_workflow_sha",
+	"ref",
+	"ref_protected",
+	"ref_type",
+	"repo",
+	"repository",
+	"repository_id",
+	"repository_owner",
+	"repository_owner_id",
+	"repository_visibility",
+	"run_attempt",
+	"run_id",
+	"run_number",
+	"runner_environment",
+	"sha",
+	"workflow",
+	"workflow_ref",
+	"workflow_sha",
+}
+
+// oidcCustomPropertyInclusion is a custom property of repositories included
+// in the OIDC tokens of an organization, which go-github does not model yet.
+type oidcCustomPropertyInclusion struct {
+	CustomPropertyName string `json:"custom_property_name"`
+	InclusionSource    string `json:"inclusion_source,omitempty"`
+}
+
+func listOIDCCustomPropertyInclusions(ctx context.Context, client *github.Client, org string) ([]*oidcCustomPropertyInclusion, error) {
+	req, err := client.NewRequest("GET", fmt.Sprintf("orgs/%v/actions/oidc/customization/properties/repo", org), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var inclusions []*oidcCustomPropertyInclusion
+	if _, err = client.Do(ctx, req, &inclusions); err != nil {
+		return nil, err
+	}
+	return inclusions, nil
+}
+
+// checkOIDCClaimKeys returns a warning for each claim key which is not a
+// known claim, or which refers to a custom property which is not one of the
+// custom properties given.
+func checkOIDCClaimKeys(keys []string, customProperties []string) []string {
+	var warnings []string
+	for _, key := range keys {
+		if name, ok := strings.CutPrefix(key, oidcCustomPropertyClaimPrefix); ok {
+			if !slices.Contains(customProperties, name) {
+				warnings = append(warnings, fmt.Sprintf("Claim key %q refers to the custom property %q, which is not included in the OIDC tokens of the organization yet", key, name))
+			}
+			continue
+		}
+		if !slices.Contains(oidcClaimKeys, key) {
+			warnings = append(warnings, fmt.Sprintf("Claim key %q is not a known claim of the OIDC tokens, GitHub may reject it", key))
+		}
+	}
+	return warnings
+}
+
+// customizeOIDCClaimKeysDiff checks include_claim_keys at plan time. The
+// custom properties are only fetched when a claim of one is used by an
+// organization. Unexpected keys are only logged: a custom property may be
+// included by a github_actions_organization_oidc_custom_property_claim
+// created in the same run, and GitHub adds claims over time.
+func customizeOIDCClaimKeysDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("include_claim_keys") {
+		return nil
+	}
+
+	keys := expandStringList(d.Get("include_claim_keys").([]interface{}))
+
+	var customProperties []string
+	for _, key := range keys {
+		if strings.HasPrefix(key, oidcCustomPropertyClaimPrefix) {
+			if checkOrganization(meta) != nil {
+				break
+			}
+			inclusions, err := listOIDCCustomPropertyInclusions(ctx, meta.(*Owner).v3client, meta.(*Owner).name)
+			if err != nil {
+				return err
+			}
+			for _, inclusion := range inclusions {
+				customProperties = append(customProperties, inclusion.CustomPropertyName)
+			}
+			break
+		}
+	}
+
+	for _, warning := range checkOIDCClaimKeys(keys, customProperties) {
+		log.Printf("[WARN] %s", warning)
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package github
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckOIDCClaimKeys(t *testing.T) {
+	cases := []struct {
+		keys    []string
+		warning string
+	}{
+		{keys: []string{"repo", "context", "job_workflow_ref"}},
+		{keys: []string{"repo", "repo_property_team"}},
+		{keys: []string{"repo", "job_workflow"}, warning: `"job_workflow" is not a known claim`},
+		{keys: []string{"repo_property_owner"}, warning: `the custom property "owner", which is not included`},
+	}
+
+	for _, c := range cases {
+		warnings := checkOIDCClaimKeys(c.keys, []string{"team"})
+		if c.warning == "" && len(warnings) > 0 {
+			t.Errorf("Expected %v to be valid, got %v", c.keys, warnings)
+		}
+		if c.warning != "" && (len(warnings) != 1 || !strings.Contains(warnings[0], c.warning)) {
+			t.Errorf("Expected %v to warn with %q, got %v", c.keys, c.warning, warnings)
+		}
+	}
+}
@@ -0,0 +1,264 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/shurcooL/githubv4"
+)
+
+// pullRequestModeConfig returns the pull_request block of a resource, or nil
+// when changes are pushed to the branch directly.
+func pullRequestModeConfig(d *schema.ResourceData) map[string]interface{} {
+	config := d.Get("pull_request").([]interface{})
+	if len(config) == 0 || config[0] == nil {
+		return nil
+	}
+	return config[0].(map[string]interface{})
+}
+
+// getPullRequestModeBaseBranch returns the branch pull requests are opened
+// against: the configured branch or the default branch of the repository.
+func getPullRequestModeBaseBranch(ctx context.Context, d *schema.ResourceData, client *github.Client, owner, repo string) (string, error) {
+	if branch, ok := d.GetOk("branch"); ok {
+		return branch.(string), nil
+	}
+
+	repository, _, err := client.Repositories.Get(ctx, owner, repo)
+	if err != nil {
+		return "", err
+	}
+	return repository.GetDefaultBranch(), nil
+}
+
+// pullRequestModeBranchPrefix prefixes the generated head branches.
+const pullRequestModeBranchPrefix = "terraform/"
+
+// generatedPullRequestModeBranchRegexp matches the head branches generated
+// with id.PrefixedUniqueId, which are the only ones that are reset.
+var generatedPullRequestModeBranchRegexp = regexp.MustCompile(`^` + pullRequestModeBranchPrefix + `[0-9]{18}[0-9a-f]{8}$`)
+
+// preparePullRequestModeBranch returns the branch changes are committed to.
+// Unless a pull request is still open from it, a generated branch is reset to
+// the tip of the base branch so that it only carries the new changes. A
+// configured branch is only fast-forwarded, as it may hold commits of its own.
+func preparePullRequestModeBranch(ctx context.Context, d *schema.ResourceData, client *github.Client, owner, repo, base string) (string, error) {
+	config := pullRequestModeConfig(d)
+
+	head := config["head_branch"].(string)
+	if head == "" {
+		head = id.PrefixedUniqueId(pullRequestModeBranchPrefix)
+		config["head_branch"] = head
+		if err := d.Set("pull_request", []interface{}{config}); err != nil {
+			return "", err
+		}
+	}
+
+	pullRequest, err := findOpenPullRequest(ctx, client, owner, repo, base, head)
+	if err != nil {
+		return "", err
+	}
+	if pullRequest != nil {
+		return head, nil
+	}
+
+	baseRef, _, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/"+base)
+	if err != nil {
+		return "", err
+	}
+	headRef := &github.Reference{
+		Ref:    github.String("refs/heads/" + head),
+		Object: &github.GitObject{SHA: baseRef.Object.SHA},
+	}
+
+	if err = checkRepositoryBranchExists(client, owner, repo, head); err != nil {
+		log.Printf("[DEBUG] Creating branch %s/%s/%s from %s", owner, repo, head, base)
+		_, _, err = client.Git.CreateRef(ctx, owner, repo, headRef)
+		return head, err
+	}
+
+	if generatedPullRequestModeBranchRegexp.MatchString(head) {
+		log.Printf("[DEBUG] Resetting branch %s/%s/%s to %s", owner, repo, head, base)
+		_, _, err = client.Git.UpdateRef(ctx, owner, repo, headRef, true)
+		return head, err
+	}
+
+	comparison, _, err := client.Repositories.CompareCommits(ctx, owner, repo, base, head, nil)
+	if err != nil {
+		return "", err
+	}
+	if comparison.GetAheadBy() > 0 {
+		return "", fmt.Errorf("branch %s of %s/%s has %d commit(s) that are not on %s, "+
+			"merge or delete it, or leave head_branch unset to use a generated branch",
+			head, owner, repo, comparison.GetAheadBy(), base)
+	}
+	if comparison.GetBehindBy() > 0 {
+		log.Printf("[DEBUG] Fast-forwarding branch %s/%s/%s to %s", owner, repo, head, base)
+		_, _, err = client.Git.UpdateRef(ctx, owner, repo, headRef, false)
+	}
+	return head, err
+}
+
+// syncPullRequestModePullRequest opens or updates the pull request once the
+// changes are committed to the head branch. When the head branch no longer
+// differs from the base branch, the pull request is closed and the head
+// branch deleted instead.
+func syncPullRequestModePullRequest(ctx context.Context, d *schema.ResourceData, meta interface{}, owner, repo, base, head, defaultTitle string) error {
+	client := meta.(*Owner).v3client
+	config := pullRequestModeConfig(d)
+
+	pullRequest, err := findOpenPullRequest(ctx, client, owner, repo, base, head)
+	if err != nil {
+		return err
+	}
+
+	comparison, _, err := client.Repositories.CompareCommits(ctx, owner, repo, base, head, nil)
+	if err != nil {
+		return err
+	}
+	if len(comparison.Files) == 0 {
+		if pullRequest != nil {
+			log.Printf("[DEBUG] Closing pull request %s/%s#%d as it has no changes left", owner, repo, pullRequest.GetNumber())
+			update := &github.PullRequest{State: github.String("closed")}
+			if _, _, err = client.PullRequests.Edit(ctx, owner, repo, pullRequest.GetNumber(), update); err != nil {
+				return err
+			}
+		}
+		if _, err = client.Git.DeleteRef(ctx, owner, repo, "heads/"+head); err != nil {
+			return err
+		}
+		return setPullRequestModeState(d, nil)
+	}
+
+	title := config["title"].(string)
+	if title == "" {
+		title = defaultTitle
+		if commitMessage, ok := d.GetOk("commit_message"); ok {
+			title = commitMessage.(string)
+		}
+	}
+	body := config["body"].(string)
+
+	if pullRequest == nil {
+		pullRequest, _, err = client.PullRequests.Create(ctx, owner, repo, &github.NewPullRequest{
+			Title: github.String(title),
+			Head:  github.String(head),
+			Base:  github.String(base),
+			Body:  github.String(body),
+		})
+		if err != nil {
+			return err
+		}
+	} else if pullRequest.GetTitle() != title || pullRequest.GetBody() != body {
+		update := &github.PullRequest{
+			Title: github.String(title),
+			Body:  github.String(body),
+		}
+		if pullRequest, _, err = client.PullRequests.Edit(ctx, owner, repo, pullRequest.GetNumber(), update); err != nil {
+			return err
+		}
+	}
+
+	if config["auto_merge"].(bool) && pullRequest.AutoMerge == nil {
+		if err = mergePullRequestModePullRequest(ctx, meta, owner, repo, pullRequest, config["merge_method"].(string)); err != nil {
+			return err
+		}
+		if pullRequest, _, err = client.PullRequests.Get(ctx, owner, repo, pullRequest.GetNumber()); err != nil {
+			return err
+		}
+	}
+
+	return setPullRequestModeState(d, pullRequest)
+}
+
+// readPullRequestModeBranch refreshes the state of the pull request and
+// returns its head branch while it is open, as the managed content lives
+// there until it is merged. An empty branch is returned otherwise.
+func readPullRequestModeBranch(ctx context.Context, d *schema.ResourceData, client *github.Client, owner, repo string) (string, error) {
+	config := pullRequestModeConfig(d)
+	if config == nil {
+		return "", nil
+	}
+
+	number := config["number"].(int)
+	if number == 0 {
+		return "", nil
+	}
+
+	pullRequest, _, err := client.PullRequests.Get(ctx, owner, repo, number)
+	if err != nil {
+		return "", err
+	}
+	if err = setPullRequestModeState(d, pullRequest); err != nil {
+		return "", err
+	}
+
+	if pullRequest.GetState() != "open" {
+		return "", nil
+	}
+	return pullRequest.GetHead().GetRef(), nil
+}
+
+// mergePullRequestModePullRequest enables auto-merge on a pull request. Since
+// GitHub refuses to do so when the pull request can already be merged, it is
+// then merged right away.
+func mergePullRequestModePullRequest(ctx context.Context, meta interface{}, owner, repo string, pullRequest *github.PullRequest, method string) error {
+	var mutate struct {
+		EnablePullRequestAutoMerge struct {
+			ClientMutationID githubv4.String
+		} `graphql:"enablePullRequestAutoMerge(input:$input)"`
+	}
+	mergeMethod := githubv4.PullRequestMergeMethod(method)
+	input := githubv4.EnablePullRequestAutoMergeInput{
+		PullRequestID: githubv4.ID(pullRequest.GetNodeID()),
+		MergeMethod:   &mergeMethod,
+	}
+
+	err := meta.(*Owner).v4client.Mutate(ctx, &mutate, input, nil)
+	if err == nil || !strings.Contains(err.Error(), "clean status") {
+		return err
+	}
+
+	log.Printf("[DEBUG] Merging pull request %s/%s#%d", owner, repo, pullRequest.GetNumber())
+	_, _, err = meta.(*Owner).v3client.PullRequests.Merge(ctx, owner, repo, pullRequest.GetNumber(), "", &github.PullRequestOptions{
+		MergeMethod: strings.ToLower(method),
+	})
+	return err
+}
+
+func findOpenPullRequest(ctx context.Context, client *github.Client, owner, repo, base, head string) (*github.PullRequest, error) {
+	pullRequests, _, err := client.PullRequests.List(ctx, owner, repo, &github.PullRequestListOptions{
+		State: "open",
+		Head:  owner + ":" + head,
+		Base:  base,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(pullRequests) == 0 {
+		return nil, nil
+	}
+	return pullRequests[0], nil
+}
+
+func setPullRequestModeState(d *schema.ResourceData, pullRequest *github.PullRequest) error {
+	config := pullRequestModeConfig(d)
+	if config == nil {
+		return nil
+	}
+
+	config["number"] = pullRequest.GetNumber()
+	config["url"] = pullRequest.GetHTMLURL()
+	config["state"] = pullRequest.GetState()
+	if pullRequest.GetMerged() {
+		config["state"] = "merged"
+	}
+
+	return d.Set("pull_request", []interface{}{config})
+}
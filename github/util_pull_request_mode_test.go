@@ -0,0 +1,425 @@
+package github
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestReadPullRequestModeBranch(t *testing.T) {
+	cases := []struct {
+		name           string
+		responseBody   string
+		expectedBranch string
+		expectedState  string
+	}{
+		{
+			name:           "open",
+			responseBody:   `{"number": 12, "state": "open", "html_url": "https://github.com/octo/hello/pull/12", "head": {"ref": "terraform/files"}}`,
+			expectedBranch: "terraform/files",
+			expectedState:  "open",
+		},
+		{
+			name:          "merged",
+			responseBody:  `{"number": 12, "state": "closed", "merged": true, "html_url": "https://github.com/octo/hello/pull/12", "head": {"ref": "terraform/files"}}`,
+			expectedState: "merged",
+		},
+		{
+			name:          "closed",
+			responseBody:  `{"number": 12, "state": "closed", "html_url": "https://github.com/octo/hello/pull/12", "head": {"ref": "terraform/files"}}`,
+			expectedState: "closed",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := githubApiMock([]*mockResponse{
+				{
+					ExpectedUri:    "/repos/octo/hello/pulls/12",
+					ExpectedMethod: "GET",
+					ResponseBody:   tc.responseBody,
+					StatusCode:     200,
+				},
+			})
+			defer ts.Close()
+
+			client := github.NewClient(nil)
+			client.BaseURL, _ = url.Parse(ts.URL + "/")
+
+			d := schema.TestResourceDataRaw(t, resourceGithubRepositoryFiles().Schema, map[string]interface{}{
+				"repository": "hello",
+				"files":      map[string]interface{}{"README.md": "hello"},
+				"pull_request": []interface{}{
+					map[string]interface{}{"head_branch": "terraform/files"},
+				},
+			})
+			config := pullRequestModeConfig(d)
+			config["number"] = 12
+			if err := d.Set("pull_request", []interface{}{config}); err != nil {
+				t.Fatal(err)
+			}
+
+			branch, err := readPullRequestModeBranch(context.Background(), d, client, "octo", "hello")
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if branch != tc.expectedBranch {
+				t.Errorf("Expected branch %q, got %q", tc.expectedBranch, branch)
+			}
+			if state := d.Get("pull_request.0.state").(string); state != tc.expectedState {
+				t.Errorf("Expected state %q, got %q", tc.expectedState, state)
+			}
+			if htmlURL := d.Get("pull_request.0.url").(string); htmlURL != "https://github.com/octo/hello/pull/12" {
+				t.Errorf("Unexpected URL %q", htmlURL)
+			}
+		})
+	}
+}
+
+func TestPreparePullRequestModeBranch(t *testing.T) {
+	generated := "terraform/20261017120000000000000001"
+
+	cases := []struct {
+		name          string
+		headBranch    string
+		responses     []*mockResponse
+		expectedError string
+	}{
+		{
+			name:       "open pull request",
+			headBranch: "feature/files",
+			responses: []*mockResponse{
+				{
+					ExpectedUri:    "/repos/octo/hello/pulls?base=main&head=octo%3Afeature%2Ffiles&state=open",
+					ExpectedMethod: "GET",
+					ResponseBody:   `[{"number": 12}]`,
+					StatusCode:     200,
+				},
+			},
+		},
+		{
+			name:       "missing branch",
+			headBranch: "feature/files",
+			responses: []*mockResponse{
+				{
+					ExpectedUri:    "/repos/octo/hello/pulls?base=main&head=octo%3Afeature%2Ffiles&state=open",
+					ExpectedMethod: "GET",
+					ResponseBody:   `[]`,
+					StatusCode:     200,
+				},
+				{
+					ExpectedUri:    "/repos/octo/hello/git/ref/heads/main",
+					ExpectedMethod: "GET",
+					ResponseBody:   `{"ref": "refs/heads/main", "object": {"sha": "b1"}}`,
+					StatusCode:     200,
+				},
+				{
+					ExpectedUri:    "/repos/octo/hello/branches/feature%2Ffiles",
+					ExpectedMethod: "GET",
+					ResponseBody:   `{"message": "Branch not found"}`,
+					StatusCode:     404,
+				},
+				{
+					ExpectedUri:    "/repos/octo/hello/git/refs",
+					ExpectedMethod: "POST",
+					ExpectedBody:   []byte(`{"ref":"refs/heads/feature/files","sha":"b1"}` + "\n"),
+					ResponseBody:   `{"ref": "refs/heads/feature/files", "object": {"sha": "b1"}}`,
+					StatusCode:     201,
+				},
+			},
+		},
+		{
+			name:       "generated branch is reset",
+			headBranch: generated,
+			responses: []*mockResponse{
+				{
+					ExpectedUri:    "/repos/octo/hello/pulls?base=main&head=octo%3Aterraform%2F20261017120000000000000001&state=open",
+					ExpectedMethod: "GET",
+					ResponseBody:   `[]`,
+					StatusCode:     200,
+				},
+				{
+					ExpectedUri:    "/repos/octo/hello/git/ref/heads/main",
+					ExpectedMethod: "GET",
+					ResponseBody:   `{"ref": "refs/heads/main", "object": {"sha": "b1"}}`,
+					StatusCode:     200,
+				},
+				{
+					ExpectedUri:    "/repos/octo/hello/branches/terraform%2F20261017120000000000000001",
+					ExpectedMethod: "GET",
+					ResponseBody:   `{"name": "` + generated + `"}`,
+					StatusCode:     200,
+				},
+				{
+					ExpectedUri:    "/repos/octo/hello/git/refs/heads/" + generated,
+					ExpectedMethod: "PATCH",
+					ExpectedBody:   []byte(`{"sha":"b1","force":true}` + "\n"),
+					ResponseBody:   `{"ref": "refs/heads/` + generated + `", "object": {"sha": "b1"}}`,
+					StatusCode:     200,
+				},
+			},
+		},
+		{
+			name:       "configured branch is fast-forwarded",
+			headBranch: "feature/files",
+			responses: []*mockResponse{
+				{
+					ExpectedUri:    "/repos/octo/hello/pulls?base=main&head=octo%3Afeature%2Ffiles&state=open",
+					ExpectedMethod: "GET",
+					ResponseBody:   `[]`,
+					StatusCode:     200,
+				},
+				{
+					ExpectedUri:    "/repos/octo/hello/git/ref/heads/main",
+					ExpectedMethod: "GET",
+					ResponseBody:   `{"ref": "refs/heads/main", "object": {"sha": "b1"}}`,
+					StatusCode:     200,
+				},
+				{
+					ExpectedUri:    "/repos/octo/hello/branches/feature%2Ffiles",
+					ExpectedMethod: "GET",
+					ResponseBody:   `{"name": "feature/files"}`,
+					StatusCode:     200,
+				},
+				{
+					ExpectedUri:    "/repos/octo/hello/compare/main...feature%2Ffiles",
+					ExpectedMethod: "GET",
+					ResponseBody:   `{"ahead_by": 0, "behind_by": 2}`,
+					StatusCode:     200,
+				},
+				{
+					ExpectedUri:    "/repos/octo/hello/git/refs/heads/feature/files",
+					ExpectedMethod: "PATCH",
+					ExpectedBody:   []byte(`{"sha":"b1","force":false}` + "\n"),
+					ResponseBody:   `{"ref": "refs/heads/feature/files", "object": {"sha": "b1"}}`,
+					StatusCode:     200,
+				},
+			},
+		},
+		{
+			name:       "configured branch has diverged",
+			headBranch: "feature/files",
+			responses: []*mockResponse{
+				{
+					ExpectedUri:    "/repos/octo/hello/pulls?base=main&head=octo%3Afeature%2Ffiles&state=open",
+					ExpectedMethod: "GET",
+					ResponseBody:   `[]`,
+					StatusCode:     200,
+				},
+				{
+					ExpectedUri:    "/repos/octo/hello/git/ref/heads/main",
+					ExpectedMethod: "GET",
+					ResponseBody:   `{"ref": "refs/heads/main", "object": {"sha": "b1"}}`,
+					StatusCode:     200,
+				},
+				{
+					ExpectedUri:    "/repos/octo/hello/branches/feature%2Ffiles",
+					ExpectedMethod: "GET",
+					ResponseBody:   `{"name": "feature/files"}`,
+					StatusCode:     200,
+				},
+				{
+					ExpectedUri:    "/repos/octo/hello/compare/main...feature%2Ffiles",
+					ExpectedMethod: "GET",
+					ResponseBody:   `{"ahead_by": 1, "behind_by": 2}`,
+					StatusCode:     200,
+				},
+			},
+			expectedError: "has 1 commit(s) that are not on main",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := githubApiMock(tc.responses)
+			defer ts.Close()
+
+			client := github.NewClient(nil)
+			client.BaseURL, _ = url.Parse(ts.URL + "/")
+
+			d := schema.TestResourceDataRaw(t, resourceGithubRepositoryFiles().Schema, map[string]interface{}{
+				"repository": "hello",
+				"files":      map[string]interface{}{"README.md": "hello"},
+				"pull_request": []interface{}{
+					map[string]interface{}{"head_branch": tc.headBranch},
+				},
+			})
+
+			head, err := preparePullRequestModeBranch(context.Background(), d, client, "octo", "hello", "main")
+			if tc.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedError) {
+					t.Fatalf("Expected error containing %q, got %v", tc.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if head != tc.headBranch {
+				t.Errorf("Expected branch %q, got %q", tc.headBranch, head)
+			}
+		})
+	}
+}
+
+func TestGeneratedPullRequestModeBranchRegexp(t *testing.T) {
+	if head := id.PrefixedUniqueId(pullRequestModeBranchPrefix); !generatedPullRequestModeBranchRegexp.MatchString(head) {
+		t.Errorf("Expected generated branch %q to match", head)
+	}
+	for _, head := range []string{"terraform/files", "feature/files", "terraform/20261017120000000000000001/files"} {
+		if generatedPullRequestModeBranchRegexp.MatchString(head) {
+			t.Errorf("Expected configured branch %q not to match", head)
+		}
+	}
+}
+
+func TestSyncPullRequestModePullRequest(t *testing.T) {
+	listUri := "/repos/octo/hello/pulls?base=main&head=octo%3Afeature%2Ffiles&state=open"
+	compareUri := "/repos/octo/hello/compare/main...feature%2Ffiles"
+
+	cases := []struct {
+		name           string
+		responses      []*mockResponse
+		expectedNumber int
+		expectedState  string
+	}{
+		{
+			name: "create",
+			responses: []*mockResponse{
+				{
+					ExpectedUri:    listUri,
+					ExpectedMethod: "GET",
+					ResponseBody:   `[]`,
+					StatusCode:     200,
+				},
+				{
+					ExpectedUri:    compareUri,
+					ExpectedMethod: "GET",
+					ResponseBody:   `{"ahead_by": 1, "files": [{"filename": "README.md"}]}`,
+					StatusCode:     200,
+				},
+				{
+					ExpectedUri:    "/repos/octo/hello/pulls",
+					ExpectedMethod: "POST",
+					ExpectedBody:   []byte(`{"title":"Update files","head":"feature/files","base":"main","body":""}` + "\n"),
+					ResponseBody:   `{"number": 12, "state": "open", "html_url": "https://github.com/octo/hello/pull/12"}`,
+					StatusCode:     201,
+				},
+			},
+			expectedNumber: 12,
+			expectedState:  "open",
+		},
+		{
+			name: "update",
+			responses: []*mockResponse{
+				{
+					ExpectedUri:    listUri,
+					ExpectedMethod: "GET",
+					ResponseBody:   `[{"number": 12, "state": "open", "title": "Old title", "body": ""}]`,
+					StatusCode:     200,
+				},
+				{
+					ExpectedUri:    compareUri,
+					ExpectedMethod: "GET",
+					ResponseBody:   `{"ahead_by": 1, "files": [{"filename": "README.md"}]}`,
+					StatusCode:     200,
+				},
+				{
+					ExpectedUri:    "/repos/octo/hello/pulls/12",
+					ExpectedMethod: "PATCH",
+					ExpectedBody:   []byte(`{"title":"Update files","body":""}` + "\n"),
+					ResponseBody:   `{"number": 12, "state": "open", "title": "Update files", "html_url": "https://github.com/octo/hello/pull/12"}`,
+					StatusCode:     200,
+				},
+			},
+			expectedNumber: 12,
+			expectedState:  "open",
+		},
+		{
+			name: "close",
+			responses: []*mockResponse{
+				{
+					ExpectedUri:    listUri,
+					ExpectedMethod: "GET",
+					ResponseBody:   `[{"number": 12, "state": "open", "title": "Update files"}]`,
+					StatusCode:     200,
+				},
+				{
+					ExpectedUri:    compareUri,
+					ExpectedMethod: "GET",
+					ResponseBody:   `{"ahead_by": 1, "files": []}`,
+					StatusCode:     200,
+				},
+				{
+					ExpectedUri:    "/repos/octo/hello/pulls/12",
+					ExpectedMethod: "PATCH",
+					ExpectedBody:   []byte(`{"state":"closed"}` + "\n"),
+					ResponseBody:   `{"number": 12, "state": "closed"}`,
+					StatusCode:     200,
+				},
+				{
+					ExpectedUri:    "/repos/octo/hello/git/refs/heads/feature/files",
+					ExpectedMethod: "DELETE",
+					StatusCode:     204,
+				},
+			},
+		},
+		{
+			name: "delete",
+			responses: []*mockResponse{
+				{
+					ExpectedUri:    listUri,
+					ExpectedMethod: "GET",
+					ResponseBody:   `[]`,
+					StatusCode:     200,
+				},
+				{
+					ExpectedUri:    compareUri,
+					ExpectedMethod: "GET",
+					ResponseBody:   `{"files": []}`,
+					StatusCode:     200,
+				},
+				{
+					ExpectedUri:    "/repos/octo/hello/git/refs/heads/feature/files",
+					ExpectedMethod: "DELETE",
+					StatusCode:     204,
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := githubApiMock(tc.responses)
+			defer ts.Close()
+
+			client := github.NewClient(nil)
+			client.BaseURL, _ = url.Parse(ts.URL + "/")
+			meta := &Owner{name: "octo", v3client: client}
+
+			d := schema.TestResourceDataRaw(t, resourceGithubRepositoryFiles().Schema, map[string]interface{}{
+				"repository": "hello",
+				"files":      map[string]interface{}{"README.md": "hello"},
+				"pull_request": []interface{}{
+					map[string]interface{}{"head_branch": "feature/files"},
+				},
+			})
+
+			err := syncPullRequestModePullRequest(context.Background(), d, meta, "octo", "hello", "main", "feature/files", "Update files")
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if number := d.Get("pull_request.0.number").(int); number != tc.expectedNumber {
+				t.Errorf("Expected number %d, got %d", tc.expectedNumber, number)
+			}
+			if state := d.Get("pull_request.0.state").(string); state != tc.expectedState {
+				t.Errorf("Expected state %q, got %q", tc.expectedState, state)
+			}
+		})
+	}
+}
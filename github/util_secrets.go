@@ -0,0 +1,86 @@
+package github
+
+import (
+	"encoding/base64"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// getSecretEncryptedValue returns the value to upload for a secret resource:
+// encrypted_value or encrypted_value_wo as is, or plaintext_value or
+// plaintext_value_wo sealed with the given public key. The write-only
+// arguments are never persisted, so they are read from the configuration.
+func getSecretEncryptedValue(d *schema.ResourceData, publicKey string) (string, error) {
+	if encryptedText, ok := d.GetOk("encrypted_value"); ok {
+		return encryptedText.(string), nil
+	}
+
+	encryptedText, err := getWriteOnlyString(d, "encrypted_value_wo")
+	if err != nil {
+		return "", err
+	}
+	if encryptedText != "" {
+		return encryptedText, nil
+	}
+
+	plaintextValue := d.Get("plaintext_value").(string)
+	if plaintextValue == "" {
+		plaintextValue, err = getWriteOnlyString(d, "plaintext_value_wo")
+		if err != nil {
+			return "", err
+		}
+	}
+
+	encryptedBytes, err := encryptPlaintext(plaintextValue, publicKey)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(encryptedBytes), nil
+}
+
+// getWriteOnlyString returns the configured value of a write-only string
+// argument, or an empty string when it is not set.
+func getWriteOnlyString(d *schema.ResourceData, key string) (string, error) {
+	value, diags := d.GetRawConfigAt(cty.GetAttrPath(key))
+	if diags.HasError() {
+		return "", fmt.Errorf("unable to read %s: %s", key, diags[0].Summary)
+	}
+
+	if !value.Type().Equals(cty.String) || value.IsNull() || !value.IsKnown() {
+		return "", nil
+	}
+	return value.AsString(), nil
+}
+
+// checkSecretDrift is a drift detection mechanism based on timestamps, shared
+// by the secret resources.
+//
+// If we do not currently store the "updated_at" field, it means we've only
+// just created the resource and the value is most likely what we want it to
+// be.
+//
+// If the resource is changed externally in the meantime then reading back
+// the last update timestamp will return a result different than the
+// timestamp we've persisted in the state. In that case, we can no longer
+// trust that the value (which we don't see) is equal to what we've declared
+// previously.
+//
+// When destroy_on_drift is set, the only solution to enforce consistency is
+// to mark the resource as deleted (unset the ID) in order to fix potential
+// drift by recreating the resource. Otherwise the external change is
+// acknowledged.
+func checkSecretDrift(d *schema.ResourceData, updatedAt string) error {
+	destroyOnDrift := d.Get("destroy_on_drift").(bool)
+	if previous, ok := d.GetOk("updated_at"); ok && previous != updatedAt {
+		log.Printf("[INFO] The secret %s has been externally updated in GitHub", d.Id())
+		if destroyOnDrift {
+			d.SetId("")
+		}
+	}
+
+	// Always update the timestamp to prevent repeated drift detection
+	return d.Set("updated_at", updatedAt)
+}
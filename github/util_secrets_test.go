@@ -0,0 +1,136 @@
+package github
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"golang.org/x/crypto/nacl/box"
+)
+
+func TestGetSecretEncryptedValue(t *testing.T) {
+	publicKey, privateKey, err := box.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicKeyB64 := base64.StdEncoding.EncodeToString(publicKey[:])
+
+	cases := []struct {
+		name       string
+		attributes map[string]string
+		rawConfig  map[string]cty.Value
+		encrypted  string
+		plaintext  string
+	}{
+		{
+			name:       "encrypted_value",
+			attributes: map[string]string{"encrypted_value": "c2VjcmV0"},
+			rawConfig:  map[string]cty.Value{"encrypted_value": cty.StringVal("c2VjcmV0")},
+			encrypted:  "c2VjcmV0",
+		},
+		{
+			name:       "plaintext_value",
+			attributes: map[string]string{"plaintext_value": "secret"},
+			rawConfig:  map[string]cty.Value{"plaintext_value": cty.StringVal("secret")},
+			plaintext:  "secret",
+		},
+		{
+			name:      "encrypted_value_wo",
+			rawConfig: map[string]cty.Value{"encrypted_value_wo": cty.StringVal("c2VjcmV0")},
+			encrypted: "c2VjcmV0",
+		},
+		{
+			name:      "plaintext_value_wo",
+			rawConfig: map[string]cty.Value{"plaintext_value_wo": cty.StringVal("secret")},
+			plaintext: "secret",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rawConfig := map[string]cty.Value{
+				"encrypted_value":    cty.NullVal(cty.String),
+				"plaintext_value":    cty.NullVal(cty.String),
+				"encrypted_value_wo": cty.NullVal(cty.String),
+				"plaintext_value_wo": cty.NullVal(cty.String),
+			}
+			for k, v := range tc.rawConfig {
+				rawConfig[k] = v
+			}
+
+			d := resourceGithubActionsSecret().Data(&terraform.InstanceState{
+				ID:         "repo:secret",
+				Attributes: tc.attributes,
+				RawConfig:  cty.ObjectVal(rawConfig),
+			})
+
+			value, err := getSecretEncryptedValue(d, publicKeyB64)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			if tc.encrypted != "" {
+				if value != tc.encrypted {
+					t.Errorf("Expected %q, got %q", tc.encrypted, value)
+				}
+				return
+			}
+
+			sealed, err := base64.StdEncoding.DecodeString(value)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			plaintext, ok := box.OpenAnonymous(nil, sealed, publicKey, privateKey)
+			if !ok {
+				t.Fatal("Unable to open the sealed value")
+			}
+			if string(plaintext) != tc.plaintext {
+				t.Errorf("Expected %q, got %q", tc.plaintext, plaintext)
+			}
+		})
+	}
+}
+
+func TestCheckSecretDrift(t *testing.T) {
+	cases := []struct {
+		name           string
+		destroyOnDrift bool
+		updatedAt      string
+		expectedID     string
+	}{
+		{name: "no previous timestamp", destroyOnDrift: true, expectedID: "secret"},
+		{name: "unchanged", destroyOnDrift: true, updatedAt: "2023-01-01 12:00:00 +0000 UTC", expectedID: "secret"},
+		{name: "drift with destroy_on_drift", destroyOnDrift: true, updatedAt: "2023-01-01 00:00:00 +0000 UTC", expectedID: ""},
+		{name: "drift without destroy_on_drift", destroyOnDrift: false, updatedAt: "2023-01-01 00:00:00 +0000 UTC", expectedID: "secret"},
+	}
+
+	newTimestamp := "2023-01-01 12:00:00 +0000 UTC"
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			raw := map[string]interface{}{
+				"secret_name":      "secret",
+				"destroy_on_drift": tc.destroyOnDrift,
+			}
+			if tc.updatedAt != "" {
+				raw["updated_at"] = tc.updatedAt
+			}
+			d := schema.TestResourceDataRaw(t, resourceGithubDependabotOrganizationSecret().Schema, raw)
+			d.SetId("secret")
+
+			if err := checkSecretDrift(d, newTimestamp); err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			if d.Id() != tc.expectedID {
+				t.Errorf("Expected ID %q, got %q", tc.expectedID, d.Id())
+			}
+			if updatedAt := d.Get("updated_at").(string); updatedAt != newTimestamp {
+				t.Errorf("Expected updated_at %q, got %q", newTimestamp, updatedAt)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/shurcooL/githubv4"
+)
+
+// projectV2NotFound reports whether a GraphQL error means the queried
+// project, field or item node no longer exists.
+func projectV2NotFound(err error) bool {
+	return strings.Contains(err.Error(), "Could not resolve to a node with the global id")
+}
+
+// getProjectV2OwnerID returns the node ID of the organization or user
+// owning projects.
+func getProjectV2OwnerID(ctx context.Context, client *githubv4.Client, login string) (githubv4.ID, error) {
+	var query struct {
+		RepositoryOwner struct {
+			ID githubv4.ID
+		} `graphql:"repositoryOwner(login:$login)"`
+	}
+	variables := map[string]interface{}{
+		"login": githubv4.String(login),
+	}
+
+	if err := client.Query(ctx, &query, variables); err != nil {
+		return nil, err
+	}
+	if query.RepositoryOwner.ID == nil {
+		return nil, fmt.Errorf("could not find an organization or user with the login %q", login)
+	}
+
+	return query.RepositoryOwner.ID, nil
+}
+
+// getProjectV2ID returns the node ID of a project from its owner and number,
+// as displayed in the project URL.
+func getProjectV2ID(ctx context.Context, client *githubv4.Client, login string, number int) (githubv4.ID, error) {
+	var query struct {
+		RepositoryOwner struct {
+			ProjectV2Owner struct {
+				ProjectV2 struct {
+					ID githubv4.ID
+				} `graphql:"projectV2(number:$number)"`
+			} `graphql:"... on ProjectV2Owner"`
+		} `graphql:"repositoryOwner(login:$login)"`
+	}
+	variables := map[string]interface{}{
+		"login":  githubv4.String(login),
+		"number": githubv4.Int(number),
+	}
+
+	if err := client.Query(ctx, &query, variables); err != nil {
+		return nil, err
+	}
+	if query.RepositoryOwner.ProjectV2Owner.ProjectV2.ID == nil {
+		return nil, fmt.Errorf("could not find project %d of %q", number, login)
+	}
+
+	return query.RepositoryOwner.ProjectV2Owner.ProjectV2.ID, nil
+}
+
+// parseProjectV2ImportID resolves an import ID written as <owner>/<number>
+// to the node ID of the project, any other ID being taken as a node ID.
+func parseProjectV2ImportID(ctx context.Context, client *githubv4.Client, id string) (string, error) {
+	parts := strings.Split(id, "/")
+	if len(parts) != 2 {
+		return id, nil
+	}
+
+	number, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return "", fmt.Errorf("invalid ID specified: supplied ID must be written as <owner>/<project_number> or be a project node ID")
+	}
+
+	projectID, err := getProjectV2ID(ctx, client, parts[0], number)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s", projectID), nil
+}
@@ -0,0 +1,92 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// selectedWorkflowRegexp matches a workflow of a runner group, such as
+// octo-org/octo-repo/.github/workflows/deploy.yml@main.
+var selectedWorkflowRegexp = regexp.MustCompile(`^([^/@]+)/([^/@]+)/(\.github/workflows/[^@]+\.ya?ml)@(.+)$`)
+
+// checkWorkflowExists returns an error when the workflow file does not exist
+// at the ref of the repository, so that a typo does not silently prevent
+// the workflow from running. Repositories outside of the owner of the
+// resource, such as those of other organizations of an enterprise, may not be
+// readable with the credentials of the provider, so a workflow that cannot be
+// read there is only reported with a warning.
+func checkWorkflowExists(ctx context.Context, client *github.Client, resourceOwner, owner, repo, path, ref string) error {
+	_, _, resp, err := client.Repositories.GetContents(ctx, owner, repo, path, &github.RepositoryContentGetOptions{Ref: ref})
+	if err == nil || resp == nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusNotFound {
+		return err
+	}
+
+	if !strings.EqualFold(owner, resourceOwner) {
+		log.Printf("[WARN] Cannot check that workflow %s exists at %s in %s/%s, which is outside of %s: %s",
+			path, ref, owner, repo, resourceOwner, err)
+		return nil
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("workflow %s does not exist at %s in %s/%s, or is not accessible", path, ref, owner, repo)
+	}
+	return err
+}
+
+// checkSelectedWorkflowsExist checks the workflows of a runner group of the
+// owner. Runner groups of an enterprise are checked against the owner of the
+// provider too, as workflows of its other organizations cannot be told apart
+// from the ones which cannot be read.
+func checkSelectedWorkflowsExist(ctx context.Context, client *github.Client, owner string, workflows []string) error {
+	for _, workflow := range workflows {
+		parts := selectedWorkflowRegexp.FindStringSubmatch(workflow)
+		if parts == nil {
+			return fmt.Errorf("%q is not a workflow, expected OWNER/REPOSITORY/.github/workflows/FILE.yml@REF", workflow)
+		}
+		if err := checkWorkflowExists(ctx, client, owner, parts[1], parts[2], parts[3], parts[4]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkRulesetRequiredWorkflowsExist checks the workflows of the
+// required_workflows rule of a ruleset of the owner.
+func checkRulesetRequiredWorkflowsExist(ctx context.Context, client *github.Client, owner string, d *schema.ResourceData) error {
+	rules := d.Get("rules").([]interface{})
+	if len(rules) == 0 || rules[0] == nil {
+		return nil
+	}
+	requiredWorkflows, ok := rules[0].(map[string]interface{})["required_workflows"].([]interface{})
+	if !ok || len(requiredWorkflows) == 0 || requiredWorkflows[0] == nil {
+		return nil
+	}
+
+	for _, w := range requiredWorkflows[0].(map[string]interface{})["required_workflow"].(*schema.Set).List() {
+		workflow := w.(map[string]interface{})
+
+		repo, resp, err := client.Repositories.GetByID(ctx, int64(workflow["repository_id"].(int)))
+		if err != nil {
+			if resp != nil && (resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusNotFound) {
+				log.Printf("[WARN] Cannot check that workflow %s exists in repository %d: %s",
+					workflow["path"], workflow["repository_id"], err)
+				continue
+			}
+			return err
+		}
+		err = checkWorkflowExists(ctx, client, owner, repo.GetOwner().GetLogin(), repo.GetName(), workflow["path"].(string), workflow["ref"].(string))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package github
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v67/github"
+)
+
+func TestCheckSelectedWorkflowsExist(t *testing.T) {
+	ts := githubApiMock([]*mockResponse{
+		{
+			ExpectedUri:    "/repos/octo/hello/contents/.github/workflows/deploy.yml?ref=main",
+			ExpectedMethod: "GET",
+			ResponseBody:   `{"type": "file", "name": "deploy.yml", "path": ".github/workflows/deploy.yml"}`,
+			StatusCode:     200,
+		},
+		{
+			ExpectedUri:    "/repos/octo/hello/contents/.github/workflows/release.yml?ref=v1",
+			ExpectedMethod: "GET",
+			ResponseBody:   `{"message": "Not Found"}`,
+			StatusCode:     404,
+		},
+	})
+	defer ts.Close()
+
+	client := github.NewClient(nil)
+	client.BaseURL, _ = url.Parse(ts.URL + "/")
+
+	err := checkSelectedWorkflowsExist(context.Background(), client, "octo", []string{
+		"octo/hello/.github/workflows/deploy.yml@main",
+		"octo/hello/.github/workflows/release.yml@v1",
+	})
+	if err == nil || !strings.Contains(err.Error(), "release.yml does not exist at v1") {
+		t.Fatalf("Expected the missing workflow to be reported, got %v", err)
+	}
+}
+
+func TestCheckSelectedWorkflowsExist_otherOwner(t *testing.T) {
+	ts := githubApiMock([]*mockResponse{
+		{
+			ExpectedUri:    "/repos/other/hello/contents/.github/workflows/deploy.yml?ref=main",
+			ExpectedMethod: "GET",
+			ResponseBody:   `{"message": "Not Found"}`,
+			StatusCode:     404,
+		},
+		{
+			ExpectedUri:    "/repos/another/hello/contents/.github/workflows/deploy.yml?ref=main",
+			ExpectedMethod: "GET",
+			ResponseBody:   `{"message": "Resource not accessible by integration"}`,
+			StatusCode:     403,
+		},
+	})
+	defer ts.Close()
+
+	client := github.NewClient(nil)
+	client.BaseURL, _ = url.Parse(ts.URL + "/")
+
+	err := checkSelectedWorkflowsExist(context.Background(), client, "octo", []string{
+		"other/hello/.github/workflows/deploy.yml@main",
+		"another/hello/.github/workflows/deploy.yml@main",
+	})
+	if err != nil {
+		t.Fatalf("Expected workflows outside of the owner that cannot be read to be skipped, got %v", err)
+	}
+}
+
+func TestSelectedWorkflowRegexp(t *testing.T) {
+	for workflow, valid := range map[string]bool{
+		"octo/hello/.github/workflows/deploy.yml@main":            true,
+		"octo/hello/.github/workflows/deploy.yaml@refs/tags/v1.0": true,
+		"octo/hello/.github/workflows/deploy.yml":                 false,
+		"octo/.github/workflows/deploy.yml@main":                  false,
+		"octo/hello/workflows/deploy.yml@main":                    false,
+	} {
+		if selectedWorkflowRegexp.MatchString(workflow) != valid {
+			t.Errorf("Expected %q to be valid: %t", workflow, valid)
+		}
+	}
+}
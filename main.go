@@ -1,11 +1,22 @@
 package main
 
 import (
+	"context"
+	"log"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
 	"github.com/integrations/terraform-provider-github/v6/github"
 )
 
 func main() {
+	providerServer, err := github.NewMuxProviderServer(context.Background())
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	plugin.Serve(&plugin.ServeOpts{
-		ProviderFunc: github.Provider})
+		GRPCProviderFunc: providerServer})
+
+	// Serve returns once Terraform shuts the provider down.
+	github.ReportAPIUsage()
 }